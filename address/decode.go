@@ -0,0 +1,373 @@
+// Package address decodes and validates the addresses of every encoding
+// the other packages generate, using the same network parameters.
+package address
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mearaj/bips/bech32"
+	"github.com/mearaj/bips/bip32"
+	"github.com/mearaj/bips/bip44"
+	"github.com/mearaj/bips/cashaddr"
+	"github.com/mearaj/bips/cosmos"
+	"github.com/mearaj/bips/eos"
+	"github.com/mearaj/bips/evm"
+	"github.com/mearaj/bips/filecoin"
+	"github.com/mearaj/bips/netparams"
+	"github.com/mearaj/bips/ripple"
+	"github.com/mearaj/bips/tron"
+)
+
+const (
+	hash160Length    = 20
+	rskCoinType      = 137
+	eosCoinType      = 194
+	tronCoinType     = 195
+	filecoinCoinType = 461
+	nexaCoinType     = 29223
+	// xAddressLength is the length of a decoded X-address, checksum included
+	xAddressLength = 2 + ripple.AccountIDLength + 1 + 8 + netparams.ChecksumLength
+)
+
+var (
+	ErrEmptyAddress            = errors.New("empty address")
+	ErrUnknownFormat           = errors.New("unknown address format")
+	ErrInvalidBase58Character  = errors.New("invalid base58 character")
+	ErrInvalidBase58Checksum   = errors.New("invalid base58check checksum")
+	ErrInvalidPayloadLength    = errors.New("invalid address payload length")
+	ErrUnknownPrefix           = errors.New("unknown address version prefix")
+	ErrUnknownHRP              = errors.New("unknown bech32 human readable part")
+	ErrUnsupportedWitness      = errors.New("unsupported witness version or program")
+	ErrInvalidHexCharacter     = errors.New("invalid hex character")
+	ErrInvalidEVMAddressLength = errors.New("EVM address must be 40 hex characters")
+)
+
+// Address is a decoded address
+type Address struct {
+	// Encoding is the address format, ex bip32.P2WPKH
+	Encoding bip32.AddrEncoding
+	// Networks are the candidate networks of base58 and bech32 addresses
+	Networks []*netparams.Params
+	// Coins are the candidate bip44 coins
+	Coins []bip44.Coin
+	// WitnessVersion is the witness version of segwit addresses
+	WitnessVersion byte
+	// Payload is the pubkey hash, the script hash, the witness program, the
+	// 20 bytes of an EVM, Tron or Filecoin address, the bytes of a Cosmos
+	// address or the compressed EOS public key
+	Payload []byte
+	// Alternatives are the other readings of a base58 prefix that is the
+	// pubkey hash prefix of a network and the script hash prefix of another
+	Alternatives []*Address
+}
+
+// Decode returns the format, the candidate networks and the payload of addr.
+// The errors tell precisely what's wrong, with the position of invalid
+// characters and of the likely typo of bech32 addresses.
+func Decode(addr string) (*Address, error) {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return nil, ErrEmptyAddress
+	}
+	if strings.HasPrefix(addr, "0x") || strings.HasPrefix(addr, "0X") {
+		return decodeEVM(addr)
+	}
+	if strings.Contains(addr, ":") {
+		return decodeCashAddr(addr)
+	}
+	if isFilecoin(addr) {
+		return decodeFilecoin(addr)
+	}
+	if strings.HasPrefix(addr, eos.PubKeyPrefix) || strings.HasPrefix(addr, eos.PubKeyK1Prefix) {
+		return decodeEOS(addr)
+	}
+	lower := strings.ToLower(addr)
+	if sep := strings.LastIndexByte(lower, '1'); sep > 0 {
+		if networks := networksOfHRP(lower[:sep]); len(networks) > 0 {
+			return decodeSegWit(addr, lower[:sep], networks)
+		}
+		if chain, addrType, err := cosmos.ByHRP(lower[:sep]); err == nil {
+			return decodeCosmos(addr, chain, addrType)
+		}
+	}
+	decoded, err := decodeBase58(addr)
+	if err != nil {
+		// report the bech32 problem of strings that aren't base58 at all
+		if _, _, bErr := bech32.Decode(addr); errors.Is(err, ErrInvalidBase58Character) && bErr == nil {
+			return nil, fmt.Errorf("%w: `%v`", ErrUnknownHRP, lower[:strings.LastIndexByte(lower, '1')])
+		}
+		return nil, err
+	}
+	return decoded, nil
+}
+
+func decodeSegWit(addr, hrp string, networks []*netparams.Params) (*Address, error) {
+	version, program, err := bech32.DecodeSegWitAddress(hrp, addr)
+	if err != nil {
+		if errors.Is(err, bech32.ErrInvalidChecksum) {
+			if positions := bech32.LocateErrors(addr); len(positions) > 0 {
+				return nil, fmt.Errorf("%w, likely error at position %v", err, positions)
+			}
+		}
+		return nil, err
+	}
+	a := &Address{Networks: networks, WitnessVersion: version, Payload: program}
+	switch {
+	case version == 0 && len(program) == hash160Length:
+		a.Encoding = bip32.P2WPKH
+	case version == 0 && len(program) == 32:
+		a.Encoding = bip32.P2WSH
+	case version == 1 && len(program) == 32:
+		a.Encoding = bip32.P2TR
+	default:
+		return nil, fmt.Errorf("%w: version %d with %d bytes", ErrUnsupportedWitness, version, len(program))
+	}
+	a.Coins = coinsOf(networks)
+	return a, nil
+}
+
+// cashAddrCoinTypes are the coin types of the CashAddr prefixes, the
+// testnets having the testnet coin type
+var cashAddrCoinTypes = map[string]uint32{
+	cashaddr.PrefixBitcoinCash:        netparams.CoinTypeBitcoinCash,
+	cashaddr.PrefixBitcoinCashTestnet: netparams.CoinTypeTestnet,
+	cashaddr.PrefixBitcoinCashRegtest: netparams.CoinTypeTestnet,
+	cashaddr.PrefixNexa:               nexaCoinType,
+	cashaddr.PrefixNexaTestnet:        netparams.CoinTypeTestnet,
+}
+
+func decodeCashAddr(addr string) (*Address, error) {
+	prefix, t, payload, err := cashaddr.Decode(addr, "")
+	if err != nil {
+		return nil, err
+	}
+	coinType, ok := cashAddrCoinTypes[prefix]
+	if !ok {
+		return nil, fmt.Errorf("%w: `%v`", cashaddr.ErrInvalidPrefix, prefix)
+	}
+	a := &Address{Coins: []bip44.Coin{bip44.RegBip44CoinsTypeToValMap[coinType]}, Payload: payload}
+	switch t {
+	case cashaddr.P2PKH:
+		a.Encoding = bip32.CashAddrP2PKH
+	case cashaddr.P2SH:
+		a.Encoding = bip32.CashAddrP2SH
+	case cashaddr.P2PKT:
+		a.Encoding = bip32.P2PKT
+	default:
+		return nil, fmt.Errorf("%w: type %d", cashaddr.ErrInvalidVersion, t)
+	}
+	return a, nil
+}
+
+// cosmosEncodings are the encodings of the Cosmos address types
+var cosmosEncodings = map[cosmos.AddrType]bip32.AddrEncoding{
+	cosmos.Account: bip32.Cosmos,
+	cosmos.ValOper: bip32.CosmosValOper,
+	cosmos.ValCons: bip32.CosmosValCons,
+}
+
+func decodeCosmos(addr string, chain *cosmos.Chain, addrType cosmos.AddrType) (*Address, error) {
+	_, payload, err := cosmos.Decode(addr)
+	if err != nil {
+		if errors.Is(err, bech32.ErrInvalidChecksum) {
+			if positions := bech32.LocateErrors(addr); len(positions) > 0 {
+				return nil, fmt.Errorf("%w, likely error at position %v", err, positions)
+			}
+		}
+		return nil, err
+	}
+	return &Address{
+		Encoding: cosmosEncodings[addrType],
+		Coins:    []bip44.Coin{bip44.RegBip44CoinsTypeToValMap[chain.CoinType]},
+		Payload:  payload,
+	}, nil
+}
+
+// isFilecoin tells if addr looks like a Filecoin secp256k1 address, longer
+// than the base58 addresses starting with the same letters, ex Zcash t1…
+func isFilecoin(addr string) bool {
+	return len(addr) == filecoin.AddressLength &&
+		(strings.HasPrefix(addr, filecoin.MainnetPrefix+"1") || strings.HasPrefix(addr, filecoin.TestnetPrefix+"1"))
+}
+
+func decodeFilecoin(addr string) (*Address, error) {
+	a, err := filecoin.ParseAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	coinType := uint32(filecoinCoinType)
+	if a.Testnet {
+		coinType = netparams.CoinTypeTestnet
+	}
+	return &Address{
+		Encoding: bip32.FilecoinSecp256k1,
+		Coins:    []bip44.Coin{bip44.RegBip44CoinsTypeToValMap[coinType]},
+		Payload:  a.Payload[:],
+	}, nil
+}
+
+// decodeEOS decodes the public keys that stand for the addresses of EOS
+func decodeEOS(addr string) (*Address, error) {
+	pubKey, err := eos.ParsePubKey(addr)
+	if err != nil {
+		return nil, err
+	}
+	encoding := bip32.EOSPubKey
+	if strings.HasPrefix(addr, eos.PubKeyK1Prefix) {
+		encoding = bip32.EOSPubKeyK1
+	}
+	return &Address{
+		Encoding: encoding,
+		Coins:    []bip44.Coin{bip44.RegBip44CoinsTypeToValMap[eosCoinType]},
+		Payload:  pubKey,
+	}, nil
+}
+
+func decodeBase58(addr string) (*Address, error) {
+	for i, c := range addr {
+		if !strings.ContainsRune(netparams.BitcoinAlphabet, c) {
+			return nil, fmt.Errorf("%w: `%c` at position %d", ErrInvalidBase58Character, c, i)
+		}
+	}
+	// the length of the payload depends on the alphabet, ex the leading r of
+	// the Ripple addresses are zero bytes
+	decoded := map[string][]byte{}
+	decodeWith := func(alphabet string) []byte {
+		if alphabet == "" {
+			alphabet = netparams.BitcoinAlphabet
+		}
+		data, ok := decoded[alphabet]
+		if !ok {
+			data, _ = netparams.DecodeBase58(addr, alphabet)
+			decoded[alphabet] = data
+		}
+		return data
+	}
+	data := decodeWith(netparams.BitcoinAlphabet)
+	var readings []*Address
+	checksumMatched := false
+	for _, params := range netparams.RegParams {
+		alphabetData := decodeWith(params.Base58Alphabet)
+		for _, candidate := range []struct {
+			encoding bip32.AddrEncoding
+			prefix   []byte
+		}{
+			{bip32.P2PKH, params.PubKeyHashPrefix},
+			{bip32.P2SH, params.ScriptHashPrefix},
+		} {
+			prefixLength := len(candidate.prefix)
+			if prefixLength == 0 || len(alphabetData) != prefixLength+hash160Length+netparams.ChecksumLength {
+				continue
+			}
+			prefix, payload, err := params.DecodeBase58Check(addr, prefixLength)
+			if err != nil {
+				continue
+			}
+			checksumMatched = true
+			if string(prefix) != string(candidate.prefix) {
+				continue
+			}
+			var reading *Address
+			for _, r := range readings {
+				if r.Encoding == candidate.encoding {
+					reading = r
+				}
+			}
+			if reading == nil {
+				reading = &Address{Encoding: candidate.encoding, Payload: payload}
+				readings = append(readings, reading)
+			}
+			reading.Networks = append(reading.Networks, params)
+		}
+	}
+	if len(readings) == 0 && len(data) == tron.AddressLength+netparams.ChecksumLength && data[0] == tron.Prefix {
+		if tronAddr, err := tron.ParseAddress(addr); err == nil {
+			return &Address{
+				Encoding: bip32.TronBase58,
+				Coins:    []bip44.Coin{bip44.RegBip44CoinsTypeToValMap[tronCoinType]},
+				Payload:  tronAddr[1:],
+			}, nil
+		}
+	}
+	if len(readings) == 0 && len(decodeWith(netparams.RippleAlphabet)) == xAddressLength {
+		if x, err := ripple.ParseXAddress(addr); err == nil {
+			return &Address{
+				Encoding: bip32.XAddress,
+				Networks: []*netparams.Params{netparams.Ripple},
+				Coins:    []bip44.Coin{netparams.Ripple.Coin},
+				Payload:  x.AccountID[:],
+			}, nil
+		}
+	}
+	if len(readings) > 0 {
+		for _, r := range readings {
+			r.Coins = coinsOf(r.Networks)
+		}
+		readings[0].Alternatives = readings[1:]
+		return readings[0], nil
+	}
+	if len(data) != 1+hash160Length+netparams.ChecksumLength && len(data) != 2+hash160Length+netparams.ChecksumLength {
+		return nil, fmt.Errorf("%w: %d bytes", ErrInvalidPayloadLength, len(data))
+	}
+	if !checksumMatched {
+		if _, _, err := netparams.Bitcoin.DecodeBase58Check(addr, 1); err != nil {
+			return nil, ErrInvalidBase58Checksum
+		}
+	}
+	return nil, fmt.Errorf("%w: `%x`", ErrUnknownPrefix, data[:len(data)-hash160Length-netparams.ChecksumLength])
+}
+
+func decodeEVM(addr string) (*Address, error) {
+	digits := addr[2:]
+	if len(digits) != 2*evm.AddressLength {
+		return nil, ErrInvalidEVMAddressLength
+	}
+	for i, c := range digits {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return nil, fmt.Errorf("%w: `%c` at position %d", ErrInvalidHexCharacter, c, i+2)
+		}
+	}
+	coinTypes := netparams.EVMCoinTypes
+	parsed, err := evm.ParseAddress(addr)
+	if errors.Is(err, evm.ErrInvalidChecksum) {
+		// mixed case that isn't EIP-55 can be the EIP-1191 checksum of RSK
+		for _, chainID := range []uint64{evm.ChainIDRSKMainnet, evm.ChainIDRSKTestnet} {
+			if parsed, err = evm.ParseAddressWithChainID(addr, chainID); err == nil {
+				coinTypes = []uint32{rskCoinType}
+				break
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	a := &Address{Encoding: bip32.EVMHex, Payload: parsed[:]}
+	for _, coinType := range coinTypes {
+		a.Coins = append(a.Coins, bip44.RegBip44CoinsTypeToValMap[coinType])
+	}
+	return a, nil
+}
+
+func networksOfHRP(hrp string) []*netparams.Params {
+	var networks []*netparams.Params
+	for _, params := range netparams.RegParams {
+		if params.HRP != "" && params.HRP == hrp {
+			networks = append(networks, params)
+		}
+	}
+	return networks
+}
+
+func coinsOf(networks []*netparams.Params) []bip44.Coin {
+	var coins []bip44.Coin
+	seen := map[bip44.Coin]bool{}
+	for _, params := range networks {
+		if !seen[params.Coin] {
+			seen[params.Coin] = true
+			coins = append(coins, params.Coin)
+		}
+	}
+	return coins
+}
@@ -0,0 +1,181 @@
+package address
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/mearaj/bips/bech32"
+	"github.com/mearaj/bips/bip32"
+	"github.com/mearaj/bips/cosmos"
+	"github.com/mearaj/bips/evm"
+	"github.com/mearaj/bips/netparams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	vectors := []struct {
+		address  string
+		encoding bip32.AddrEncoding
+		network  *netparams.Params
+		payload  string
+	}{
+		{"1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", bip32.P2PKH, netparams.Bitcoin, "d986ed01b7a22225a70edbf2ba7cfb63a15cb3aa"},
+		{"2Mww8dCYPUpKHofjgcXcBCEGmniw9CoaiD2", bip32.P2SH, netparams.BitcoinTestnet, "336caa13e08b96080a32b5d818d59b4ab3b36742"},
+		{"bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", bip32.P2WPKH, netparams.Bitcoin, "c0cebcd6c3d3ca8c75dc5ec62ebe55330ef910e2"},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", bip32.P2WSH, netparams.BitcoinTestnet, "1863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", bip32.P2TR, netparams.Bitcoin, "a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c"},
+		{"0x9858EfFD232B4033E47d90003D41EC34EcaEda94", bip32.EVMHex, nil, "9858effd232b4033e47d90003d41ec34ecaeda94"},
+		{"TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqeL", bip32.TronBase58, nil, "8840e6c55b9ada326d211d818c34a994aeced808"},
+		{"DsUZxxoHJSty8DCfwfartwTYbuhmVct7tJu", bip32.P2PKH, netparams.Decred, "2789d58cfa0957d206f025c2af056fc8a77cebb0"},
+		{"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", bip32.P2PKH, netparams.Ripple, "b5f762798a53d543a014caf8b297cff8f2f937e8"},
+		// ACCOUNT_ZERO and ACCOUNT_ONE of the XRP Ledger, their leading r are
+		// zero bytes of the Ripple alphabet but not of the bitcoin one
+		{"rrrrrrrrrrrrrrrrrrrrrhoLvTp", bip32.P2PKH, netparams.Ripple, "0000000000000000000000000000000000000000"},
+		{"rrrrrrrrrrrrrrrrrrrrBZbvji", bip32.P2PKH, netparams.Ripple, "0000000000000000000000000000000000000001"},
+		{"X7AcgcsBL6XDcUb289X4mJ8djcdyKaB5hJDWMArnXr61cqZ", bip32.XAddress, netparams.Ripple, "5e7b112523f68d2f5e879db4eac51c6698a69304"},
+		{"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", bip32.CashAddrP2PKH, nil, "76a04053bda0a88bda5177b86a15c3b29f559873"},
+		{"bchtest:pr6m7j9njldwwzlg9v7v53unlr4jkmx6eyvwc0uz5t", bip32.CashAddrP2SH, nil, "f5bf48b397dae70be82b3cca4793f8eb2b6cdac9"},
+		{"cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4", bip32.Cosmos, nil, "28ff5c6d57d8cfd492b6fb42614536ed648e01fd"},
+		{"f15ihq5ibzwki2b4ep2f46avlkrqzhpqgtga7pdrq", bip32.FilecoinSecp256k1, nil, "ea0f0ea039b291a0f08fd179e0556a8c3277c0d3"},
+		{"EOS6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CV", bip32.EOSPubKey, nil, "02c0ded2bc1f1305fb0faac5e6c03ee3a1924234985427b6167ca569d13df435cf"},
+		{"PUB_K1_6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5BoDq63", bip32.EOSPubKeyK1, nil, "02c0ded2bc1f1305fb0faac5e6c03ee3a1924234985427b6167ca569d13df435cf"},
+	}
+	for _, v := range vectors {
+		a, err := Decode(v.address)
+		if !assert.NoError(t, err, v.address) {
+			continue
+		}
+		assert.Equal(t, v.encoding, a.Encoding, v.address)
+		assert.Equal(t, v.payload, hex.EncodeToString(a.Payload), v.address)
+		assert.NotEmpty(t, a.Coins, v.address)
+		if v.network != nil {
+			assert.Contains(t, a.Networks, v.network, v.address)
+		}
+	}
+}
+
+func TestDecodeCosmosAddrTypes(t *testing.T) {
+	payload, _ := hex.DecodeString("28ff5c6d57d8cfd492b6fb42614536ed648e01fd")
+	vectors := []struct {
+		chain    *cosmos.Chain
+		addrType cosmos.AddrType
+		encoding bip32.AddrEncoding
+	}{
+		{cosmos.CosmosHub, cosmos.Account, bip32.Cosmos},
+		{cosmos.CosmosHub, cosmos.ValOper, bip32.CosmosValOper},
+		{cosmos.CosmosHub, cosmos.ValCons, bip32.CosmosValCons},
+		{cosmos.Irisnet, cosmos.ValOper, bip32.CosmosValOper},
+		{cosmos.Irisnet, cosmos.ValCons, bip32.CosmosValCons},
+	}
+	for _, v := range vectors {
+		hrp, err := v.chain.HRPOf(v.addrType)
+		require.NoError(t, err)
+		addr, err := cosmos.AddressFromBytes(hrp, payload)
+		require.NoError(t, err)
+		a, err := Decode(addr)
+		require.NoError(t, err, addr)
+		assert.Equal(t, v.encoding, a.Encoding, addr)
+		assert.Equal(t, payload, a.Payload, addr)
+		require.Len(t, a.Coins, 1)
+		assert.Equal(t, v.chain.CoinType, a.Coins[0].Type, addr)
+	}
+}
+
+func TestDecodeEVMChecksum(t *testing.T) {
+	a, err := Decode("0x5aaEB6053f3e94c9b9a09f33669435E7ef1bEAeD")
+	require.NoError(t, err)
+	require.Len(t, a.Coins, 1)
+	assert.Equal(t, "RBTC", a.Coins[0].Symbol)
+
+	a, err = Decode("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	require.NoError(t, err)
+	assert.Len(t, a.Coins, len(netparams.EVMCoinTypes))
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	hash := make([]byte, 20)
+	for i := range hash {
+		hash[i] = byte(i * 11)
+	}
+	for _, params := range netparams.RegParams {
+		addr, err := params.AddrFromPubKeyHash(hash)
+		require.NoError(t, err)
+		a, err := Decode(addr)
+		require.NoError(t, err, addr)
+		a = reading(a, bip32.P2PKH)
+		assert.Contains(t, a.Networks, params, addr)
+		assert.Equal(t, hash, a.Payload)
+
+		if len(params.ScriptHashPrefix) > 0 {
+			addr, err = params.AddrFromScriptHash(hash)
+			require.NoError(t, err)
+			a, err = Decode(addr)
+			require.NoError(t, err, addr)
+			a = reading(a, bip32.P2SH)
+			assert.Contains(t, a.Networks, params, addr)
+		}
+
+		if params.HRP != "" {
+			addr, err = bech32.EncodeSegWitAddress(params.HRP, 0, hash)
+			require.NoError(t, err)
+			a, err = Decode(addr)
+			require.NoError(t, err, addr)
+			assert.Equal(t, bip32.P2WPKH, a.Encoding, addr)
+			assert.Contains(t, a.Networks, params, addr)
+		}
+	}
+}
+
+// reading returns the reading of a with the encoding, a itself or one of its alternatives
+func reading(a *Address, encoding bip32.AddrEncoding) *Address {
+	for _, r := range append([]*Address{a}, a.Alternatives...) {
+		if r.Encoding == encoding {
+			return r
+		}
+	}
+	return &Address{}
+}
+
+func TestDecodeAmbiguousPrefix(t *testing.T) {
+	// 0x3f is the Syscoin pubkey hash prefix and the DigiByte script hash one
+	addr, err := netparams.Syscoin.AddrFromPubKeyHash(make([]byte, 20))
+	require.NoError(t, err)
+	a, err := Decode(addr)
+	require.NoError(t, err)
+	require.Len(t, a.Alternatives, 1)
+	assert.Equal(t, bip32.P2SH, a.Encoding)
+	assert.Equal(t, []*netparams.Params{netparams.DigiByte}, a.Networks)
+	assert.Equal(t, bip32.P2PKH, a.Alternatives[0].Encoding)
+	assert.Equal(t, []*netparams.Params{netparams.Syscoin}, a.Alternatives[0].Networks)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for addr, expected := range map[string]error{
+		"":                                   ErrEmptyAddress,
+		"1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabB": ErrInvalidBase58Checksum,
+		"1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeab0": ErrInvalidBase58Character,
+		"1LqBGSKuX5yYUonjxT5qGf":             ErrInvalidPayloadLength,
+		"bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyv":          bech32.ErrInvalidChecksum,
+		"bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyb":          bech32.ErrInvalidCharacter,
+		"0x9858EfFD232B4033E47d90003D41EC34EcaEda9":           ErrInvalidEVMAddressLength,
+		"0x9858EfFD232B4033E47d90003D41EC34EcaEda9g":          ErrInvalidHexCharacter,
+		"bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs":                ErrUnsupportedWitness,
+		"5Kb8kLf9zgWQnogidDA76MzPL6TsZZY36hWXMssSzNydYXYB9KF": ErrInvalidPayloadLength,
+	} {
+		_, err := Decode(addr)
+		assert.ErrorIs(t, err, expected, addr)
+	}
+
+	_, err := Decode("0x9858EfFD232B4033E47d90003D41EC34EcaEda9A")
+	assert.ErrorIs(t, err, evm.ErrInvalidChecksum)
+
+	unknownHRP, _ := bech32.EncodeSegWitAddress("xyz", 0, make([]byte, 20))
+	_, err = Decode(unknownHRP)
+	assert.ErrorIs(t, err, ErrUnknownHRP)
+
+	_, err = Decode("bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyv")
+	assert.Contains(t, err.Error(), "position [41]")
+	_, err = Decode("bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyb")
+	assert.Contains(t, err.Error(), "position 41")
+}
@@ -0,0 +1,205 @@
+package address
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mearaj/bips/bech32"
+	"github.com/mearaj/bips/bip32"
+	"github.com/mearaj/bips/bip44"
+	"github.com/mearaj/bips/bip86"
+	"github.com/mearaj/bips/cashaddr"
+	"github.com/mearaj/bips/cosmos"
+	"github.com/mearaj/bips/eos"
+	"github.com/mearaj/bips/evm"
+	"github.com/mearaj/bips/filecoin"
+	"github.com/mearaj/bips/netparams"
+	"github.com/mearaj/bips/ripple"
+	"github.com/mearaj/bips/tron"
+)
+
+var ErrNotSupported = errors.New("address encoding is not supported")
+
+// AddressEncoder returns the address of a public key, compressed or not
+type AddressEncoder interface {
+	EncodeAddress(pubKey []byte) (string, error)
+}
+
+// EncoderFunc adapts a function to the AddressEncoder interface
+type EncoderFunc func(pubKey []byte) (string, error)
+
+// EncodeAddress returns f(pubKey)
+func (f EncoderFunc) EncodeAddress(pubKey []byte) (string, error) {
+	return f(pubKey)
+}
+
+type registryKey struct {
+	coinType uint32
+	encoding bip32.AddrEncoding
+}
+
+var registry = struct {
+	sync.RWMutex
+	encoders map[registryKey]AddressEncoder
+	// encodings are the encodings of each coin type in registration order
+	encodings map[uint32][]bip32.AddrEncoding
+}{
+	encoders:  map[registryKey]AddressEncoder{},
+	encodings: map[uint32][]bip32.AddrEncoding{},
+}
+
+// Register makes encoder the address encoder of the coin for the encoding,
+// replacing the previous one. It's meant to add chains and formats from
+// outside this package, ex in the init function of the package adding them.
+func Register(coin bip44.Coin, encoding bip32.AddrEncoding, encoder AddressEncoder) {
+	registry.Lock()
+	defer registry.Unlock()
+	key := registryKey{coin.Type, encoding}
+	if _, ok := registry.encoders[key]; !ok {
+		registry.encodings[coin.Type] = append(registry.encodings[coin.Type], encoding)
+	}
+	registry.encoders[key] = encoder
+}
+
+// Encoder returns the registered address encoder of the coin for the encoding
+func Encoder(coin bip44.Coin, encoding bip32.AddrEncoding) (AddressEncoder, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	encoder, ok := registry.encoders[registryKey{coin.Type, encoding}]
+	if !ok {
+		return nil, fmt.Errorf("%w: `%v` for `%v`", ErrNotSupported, encoding, coin)
+	}
+	return encoder, nil
+}
+
+// Encodings returns the encodings registered for the coin, in registration order
+func Encodings(coin bip44.Coin) []bip32.AddrEncoding {
+	registry.RLock()
+	defer registry.RUnlock()
+	return append([]bip32.AddrEncoding(nil), registry.encodings[coin.Type]...)
+}
+
+// Encode returns the address of pubKey for the coin in the encoding
+func Encode(coin bip44.Coin, encoding bip32.AddrEncoding, pubKey []byte) (string, error) {
+	encoder, err := Encoder(coin, encoding)
+	if err != nil {
+		return "", err
+	}
+	return encoder.EncodeAddress(pubKey)
+}
+
+// The encoders of the registered networks and chains, the first network or
+// chain of a coin type wins so that the testnet coin gets the bitcoin testnet
+// addresses and coin type 118 the Cosmos Hub ones
+func init() {
+	for _, params := range netparams.RegParams {
+		if len(Encodings(params.Coin)) > 0 {
+			continue
+		}
+		registerNetwork(params)
+	}
+	for _, coinType := range netparams.EVMCoinTypes {
+		coin := bip44.RegBip44CoinsTypeToValMap[coinType]
+		Register(coin, bip32.EVMHex, evmEncoder(coinType))
+	}
+	Register(bip44.RegBip44CoinsTypeToValMap[tronCoinType], bip32.TronBase58, EncoderFunc(func(pubKey []byte) (string, error) {
+		addr, err := tron.PubKeyToAddress(pubKey)
+		if err != nil {
+			return "", err
+		}
+		return addr.Base58(), nil
+	}))
+	Register(netparams.Ripple.Coin, bip32.XAddress, EncoderFunc(func(pubKey []byte) (string, error) {
+		classic, err := netparams.Ripple.AddrP2PKH(pubKey)
+		if err != nil {
+			return "", err
+		}
+		x, err := ripple.NewXAddress(classic, 0, false, false)
+		if err != nil {
+			return "", err
+		}
+		return x.String(), nil
+	}))
+	Register(netparams.BitcoinCash.Coin, bip32.CashAddrP2PKH, cashAddrEncoder(cashaddr.PrefixBitcoinCash))
+	nexa := bip44.RegBip44CoinsTypeToValMap[nexaCoinType]
+	Register(nexa, bip32.P2PKT, EncoderFunc(func(pubKey []byte) (string, error) {
+		return cashaddr.AddrP2PKT(cashaddr.PrefixNexa, pubKey)
+	}))
+	Register(nexa, bip32.CashAddrP2PKH, cashAddrEncoder(cashaddr.PrefixNexa))
+	Register(bip44.RegBip44CoinsTypeToValMap[filecoinCoinType], bip32.FilecoinSecp256k1, EncoderFunc(func(pubKey []byte) (string, error) {
+		addr, err := filecoin.PubKeyToAddress(pubKey, false)
+		if err != nil {
+			return "", err
+		}
+		return addr.String(), nil
+	}))
+	eosCoin := bip44.RegBip44CoinsTypeToValMap[eosCoinType]
+	Register(eosCoin, bip32.EOSPubKey, EncoderFunc(eos.PubKeyToString))
+	Register(eosCoin, bip32.EOSPubKeyK1, EncoderFunc(eos.PubKeyToK1String))
+	for _, chain := range cosmos.RegChains {
+		coin := bip44.RegBip44CoinsTypeToValMap[chain.CoinType]
+		if _, err := Encoder(coin, bip32.Cosmos); err == nil {
+			continue
+		}
+		Register(coin, bip32.Cosmos, EncoderFunc(func(pubKey []byte) (string, error) {
+			return chain.Address(pubKey, cosmos.Account)
+		}))
+	}
+}
+
+func registerNetwork(params *netparams.Params) {
+	Register(params.Coin, bip32.P2PKH, EncoderFunc(params.AddrP2PKH))
+	if params.HRP == "" {
+		return
+	}
+	Register(params.Coin, bip32.P2WPKHInP2SH, EncoderFunc(func(pubKey []byte) (string, error) {
+		pubKeyHash, err := params.HashOf(pubKey)
+		if err != nil {
+			return "", err
+		}
+		return params.AddrP2SH(append([]byte{0x00, 0x14}, pubKeyHash...))
+	}))
+	Register(params.Coin, bip32.P2WPKH, EncoderFunc(func(pubKey []byte) (string, error) {
+		pubKeyHash, err := params.HashOf(pubKey)
+		if err != nil {
+			return "", err
+		}
+		return bech32.EncodeSegWitAddress(params.HRP, 0, pubKeyHash)
+	}))
+	if params.Coin.Type != netparams.CoinTypeBitcoin && params.Coin.Type != netparams.CoinTypeTestnet {
+		return
+	}
+	Register(params.Coin, bip32.P2TR, EncoderFunc(func(pubKey []byte) (string, error) {
+		outputKey, err := bip86.TweakPubKey(pubKey, nil)
+		if err != nil {
+			return "", err
+		}
+		return bech32.EncodeSegWitAddress(params.HRP, 1, outputKey)
+	}))
+}
+
+// cashAddrEncoder returns the CashAddr P2PKH address with the prefix
+func cashAddrEncoder(prefix string) AddressEncoder {
+	return EncoderFunc(func(pubKey []byte) (string, error) {
+		pubKeyHash, err := bip32.HashRipeMD160onSha256(pubKey)
+		if err != nil {
+			return "", err
+		}
+		return cashaddr.Encode(prefix, cashaddr.P2PKH, pubKeyHash)
+	})
+}
+
+// evmEncoder returns the EIP-55 address, the EIP-1191 one for RSK
+func evmEncoder(coinType uint32) AddressEncoder {
+	return EncoderFunc(func(pubKey []byte) (string, error) {
+		addr, err := evm.PubKeyToAddress(pubKey)
+		if err != nil {
+			return "", err
+		}
+		if coinType == rskCoinType {
+			return addr.ChecksumHex(evm.ChainIDRSKMainnet), nil
+		}
+		return addr.Hex(), nil
+	})
+}
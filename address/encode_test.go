@@ -0,0 +1,65 @@
+package address
+
+import (
+	"testing"
+
+	"github.com/mearaj/bips/bip32"
+	"github.com/mearaj/bips/bip44"
+	"github.com/mearaj/bips/netparams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodings(t *testing.T) {
+	bitcoin := bip44.RegBip44CoinsTypeToValMap[netparams.CoinTypeBitcoin]
+	assert.Equal(t, []bip32.AddrEncoding{bip32.P2PKH, bip32.P2WPKHInP2SH, bip32.P2WPKH, bip32.P2TR}, Encodings(bitcoin))
+	assert.Equal(t, []bip32.AddrEncoding{bip32.P2PKH}, Encodings(netparams.Dogecoin.Coin))
+	assert.Equal(t, []bip32.AddrEncoding{bip32.EVMHex}, Encodings(bip44.RegBip44CoinsTypeToValMap[60]))
+	assert.Equal(t, []bip32.AddrEncoding{bip32.P2PKT, bip32.CashAddrP2PKH}, Encodings(bip44.RegBip44CoinsTypeToValMap[nexaCoinType]))
+	for _, params := range netparams.RegParams {
+		assert.NotEmpty(t, Encodings(params.Coin), params.Name)
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	pubKey := make([]byte, 33)
+	pubKey[0] = 0x02
+	// the generator point is a valid key for the taproot tweak
+	copy(pubKey[1:], []byte{
+		0x79, 0xbe, 0x66, 0x7e, 0xf9, 0xdc, 0xbb, 0xac, 0x55, 0xa0, 0x62, 0x95, 0xce, 0x87, 0x0b, 0x07,
+		0x02, 0x9b, 0xfc, 0xdb, 0x2d, 0xce, 0x28, 0xd9, 0x59, 0xf2, 0x81, 0x5b, 0x16, 0xf8, 0x17, 0x98,
+	})
+	for _, params := range netparams.RegParams {
+		for _, encoding := range Encodings(params.Coin) {
+			addr, err := Encode(params.Coin, encoding, pubKey)
+			require.NoError(t, err, params.Name)
+			a, err := Decode(addr)
+			require.NoError(t, err, addr)
+			var coins []bip44.Coin
+			for _, r := range append([]*Address{a}, a.Alternatives...) {
+				coins = append(coins, r.Coins...)
+			}
+			assert.Contains(t, coins, params.Coin, addr)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	coin := bip44.RegBip44CoinsTypeToValMap[netparams.CoinTypeDogecoin]
+	_, err := Encode(coin, bip32.P2TR, nil)
+	assert.ErrorIs(t, err, ErrNotSupported)
+
+	Register(coin, bip32.P2TR, EncoderFunc(func(pubKey []byte) (string, error) {
+		return "custom", nil
+	}))
+	t.Cleanup(func() {
+		registry.Lock()
+		defer registry.Unlock()
+		delete(registry.encoders, registryKey{coin.Type, bip32.P2TR})
+		registry.encodings[coin.Type] = registry.encodings[coin.Type][:1]
+	})
+	addr, err := Encode(coin, bip32.P2TR, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "custom", addr)
+	assert.Equal(t, []bip32.AddrEncoding{bip32.P2PKH, bip32.P2TR}, Encodings(coin))
+}
@@ -0,0 +1,433 @@
+package aezeed
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// This file implements AEZ v5, the authenticated encryption scheme of
+// aezeed, as github.com/Yawning/aez which lnd uses: key extraction,
+// AEZ-hash, AEZ-prf for empty messages, AEZ-tiny for messages shorter than
+// 32 bytes and AEZ-core for the others.
+//
+// Ref https://web.cs.ucdavis.edu/~rogaway/aez/aez.pdf
+
+const (
+	aezBlockSize        = 16
+	aezExtractedKeySize = 3 * aezBlockSize
+	// aezMinCoreSize is the size from which AEZ enciphers with AEZ-core
+	aezMinCoreSize = 2 * aezBlockSize
+)
+
+var errAEZAuth = errors.New("aez: message authentication failed")
+
+type aezBlock = [aezBlockSize]byte
+
+// aezKey is the extracted key I || J || L of AEZ
+type aezKey struct {
+	i, j, l aezBlock
+}
+
+// newAEZKey extracts the AEZ key of key, a BLAKE2b-384 hash unless key is
+// already 48 bytes long
+func newAEZKey(key []byte) *aezKey {
+	extracted := key
+	if len(key) != aezExtractedKeySize {
+		h, _ := blake2b.New384(nil)
+		h.Write(key)
+		extracted = h.Sum(nil)
+	}
+	k := &aezKey{}
+	copy(k.i[:], extracted[:aezBlockSize])
+	copy(k.j[:], extracted[aezBlockSize:2*aezBlockSize])
+	copy(k.l[:], extracted[2*aezBlockSize:])
+	return k
+}
+
+// e is the tweakable block cipher E^{j,i} of AEZ: AES10 of x xored with iL
+// for j = -1, else AES4 of x xored with jJ + 2^⌈i/8⌉ I + (i mod 8) L
+func (k *aezKey) e(j int, i int, x aezBlock) aezBlock {
+	if j == -1 {
+		xorBlock(&x, gfMulBlock(i, k.l))
+		return k.aes10(x)
+	}
+	delta := gfMulBlock(j, k.j)
+	iI := k.i
+	for n := 0; n < (i+7)/8; n++ {
+		iI = gfDouble(iI)
+	}
+	xorBlock(&delta, iI)
+	xorBlock(&delta, gfMulBlock(i%8, k.l))
+	xorBlock(&x, delta)
+	return k.aes4(x)
+}
+
+// aes4 applies four AES rounds with the round keys J, I, L and 0
+func (k *aezKey) aes4(x aezBlock) aezBlock {
+	for _, rk := range []*aezBlock{&k.j, &k.i, &k.l, {}} {
+		x = aesRound(x, rk)
+	}
+	return x
+}
+
+// aes10 applies ten AES rounds with the round keys I, J, L, I, J, L, I, J,
+// L and I
+func (k *aezKey) aes10(x aezBlock) aezBlock {
+	for r := 0; r < 10; r++ {
+		x = aesRound(x, []*aezBlock{&k.i, &k.j, &k.l}[r%3])
+	}
+	return x
+}
+
+// hash is AEZ-hash of the tweak (tau, nonce, ad...), tau in bits
+func (k *aezKey) hash(tau int, nonce []byte, ad [][]byte) aezBlock {
+	var t aezBlock
+	binary.BigEndian.PutUint32(t[12:], uint32(tau))
+	delta := k.e(3, 1, t)
+	for n, data := range append([][]byte{nonce}, ad...) {
+		j := 4 + n
+		i := 1
+		for ; len(data) >= aezBlockSize; i, data = i+1, data[aezBlockSize:] {
+			var x aezBlock
+			copy(x[:], data)
+			xorBlock(&delta, k.e(j, i, x))
+		}
+		if len(data) > 0 || i == 1 {
+			xorBlock(&delta, k.e(j, 0, pad10(data)))
+		}
+	}
+	return delta
+}
+
+// prf is AEZ-prf, the tau bytes authenticating an empty message
+func (k *aezKey) prf(delta aezBlock, tau int) []byte {
+	out := make([]byte, 0, tau+aezBlockSize)
+	var ctr aezBlock
+	for len(out) < tau {
+		x := delta
+		xorBlock(&x, ctr)
+		y := k.e(-1, 3, x)
+		out = append(out, y[:]...)
+		for i := aezBlockSize - 1; i >= 0; i-- {
+			ctr[i]++
+			if ctr[i] != 0 {
+				break
+			}
+		}
+	}
+	return out[:tau]
+}
+
+// encipher enciphers in with AEZ-tiny or AEZ-core, or deciphers it when
+// decipher is set
+func (k *aezKey) encipher(delta aezBlock, in []byte, decipher bool) []byte {
+	if len(in) < aezMinCoreSize {
+		return k.tiny(delta, in, decipher)
+	}
+	return k.core(delta, in, decipher)
+}
+
+// core is AEZ-core, the enciphering of messages of 32 bytes or more. It is
+// the inverse of itself when decipher is set.
+func (k *aezKey) core(delta aezBlock, in []byte, decipher bool) []byte {
+	d := 0
+	if decipher {
+		d = 1
+	}
+	out := make([]byte, len(in))
+	fragLen := len(in) % aezMinCoreSize
+	pairsLen := len(in) - fragLen - aezMinCoreSize
+
+	// first pass over the pairs of blocks, X sums the second blocks
+	var x, y aezBlock
+	for n := 0; n < pairsLen; n += aezMinCoreSize {
+		i := n/aezMinCoreSize + 1
+		w := block(in[n:])
+		xorBlock(&w, k.e(1, i, block(in[n+aezBlockSize:])))
+		xi := block(in[n+aezBlockSize:])
+		xorBlock(&xi, k.e(0, 0, w))
+		copy(out[n:], w[:])
+		copy(out[n+aezBlockSize:], xi[:])
+		xorBlock(&x, xi)
+	}
+	frag := in[pairsLen : pairsLen+fragLen]
+	if fragLen >= aezBlockSize {
+		xorBlock(&x, k.e(0, 4, block(frag)))
+		xorBlock(&x, k.e(0, 5, pad10(frag[aezBlockSize:])))
+	} else if fragLen > 0 {
+		xorBlock(&x, k.e(0, 4, pad10(frag)))
+	}
+
+	// the last two blocks give S
+	last := len(in) - aezMinCoreSize
+	sx := block(in[last:])
+	xorBlock(&sx, x)
+	xorBlock(&sx, delta)
+	xorBlock(&sx, k.e(0, 1+d, block(in[last+aezBlockSize:])))
+	sy := block(in[last+aezBlockSize:])
+	xorBlock(&sy, k.e(-1, 1+d, sx))
+	s := sx
+	xorBlock(&s, sy)
+
+	// second pass over the pairs of blocks, Y sums their first blocks
+	for n := 0; n < pairsLen; n += aezMinCoreSize {
+		i := n/aezMinCoreSize + 1
+		f := k.e(2, i, s)
+		w, xi := block(out[n:]), block(out[n+aezBlockSize:])
+		xorBlock(&w, f)
+		xorBlock(&xi, f)
+		xorBlock(&y, w)
+		xorBlock(&w, k.e(0, 0, xi))
+		xorBlock(&xi, k.e(1, i, w))
+		copy(out[n:], xi[:])
+		copy(out[n+aezBlockSize:], w[:])
+	}
+	if fragLen > 0 {
+		n := 0
+		if fragLen >= aezBlockSize {
+			c := block(frag)
+			xorBlock(&c, k.e(-1, 4, s))
+			copy(out[pairsLen:], c[:])
+			xorBlock(&y, k.e(0, 4, c))
+			n = aezBlockSize
+		}
+		pad := k.e(-1, 4+n/aezBlockSize, s)
+		c := make([]byte, fragLen-n)
+		for b := range c {
+			c[b] = frag[n+b] ^ pad[b]
+		}
+		copy(out[pairsLen+n:], c)
+		xorBlock(&y, k.e(0, 4+n/aezBlockSize, pad10(c)))
+	}
+
+	// the last two blocks are swapped
+	cx := sx
+	xorBlock(&cx, k.e(-1, 2-d, sy))
+	cy := sy
+	xorBlock(&cy, k.e(0, 2-d, cx))
+	xorBlock(&cy, delta)
+	xorBlock(&cy, y)
+	copy(out[last:], cy[:])
+	copy(out[last+aezBlockSize:], cx[:])
+	return out
+}
+
+// tiny is AEZ-tiny, the enciphering of messages shorter than 32 bytes. It
+// is the inverse of itself when decipher is set.
+func (k *aezKey) tiny(delta aezBlock, in []byte, decipher bool) []byte {
+	m := len(in) * 8
+	n := m / 2
+	rounds, j := 8, 6
+	switch {
+	case m == 8:
+		rounds, j = 24, 7
+	case m == 16:
+		rounds, j = 16, 7
+	case m < 128:
+		rounds, j = 10, 7
+	}
+	in = append([]byte{}, in...)
+	if decipher && m < 128 {
+		k.tinyFixFirstBit(delta, in)
+	}
+	l, r := getBits(in, 0, n), getBits(in, n, n)
+	for round := 0; round < rounds; round++ {
+		i := round
+		if decipher {
+			i = rounds - 1 - round
+		}
+		x := r
+		setBit(&x, n)
+		xorBlock(&x, delta)
+		x[aezBlockSize-1] ^= byte(i)
+		f := k.e(0, j, x)
+		clearBitsFrom(&f, n)
+		xorBlock(&l, f)
+		l, r = r, l
+	}
+	out := make([]byte, len(in))
+	putBits(out, 0, r, n)
+	putBits(out, n, l, n)
+	if !decipher && m < 128 {
+		k.tinyFixFirstBit(delta, out)
+	}
+	return out
+}
+
+// tinyFixFirstBit xors the first bit of c with the first bit of
+// E^{0,3}(delta + (c | 10*)), the last step of enciphering short messages
+func (k *aezKey) tinyFixFirstBit(delta aezBlock, c []byte) {
+	var x aezBlock
+	copy(x[:], c)
+	x[0] |= 0x80
+	xorBlock(&x, delta)
+	c[0] ^= k.e(0, 3, x)[0] & 0x80
+}
+
+// aezEncrypt is AEZ encryption with a ciphertext expansion of tau bytes
+func aezEncrypt(key, nonce []byte, ad [][]byte, tau int, plaintext []byte) []byte {
+	k := newAEZKey(key)
+	delta := k.hash(tau*8, nonce, ad)
+	if len(plaintext) == 0 {
+		return k.prf(delta, tau)
+	}
+	x := append(append([]byte{}, plaintext...), make([]byte, tau)...)
+	return k.encipher(delta, x, false)
+}
+
+// aezDecrypt reverses aezEncrypt and authenticates the ciphertext
+func aezDecrypt(key, nonce []byte, ad [][]byte, tau int, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < tau {
+		return nil, errAEZAuth
+	}
+	k := newAEZKey(key)
+	delta := k.hash(tau*8, nonce, ad)
+	if len(ciphertext) == tau {
+		if subtle.ConstantTimeCompare(k.prf(delta, tau), ciphertext) != 1 {
+			return nil, errAEZAuth
+		}
+		return []byte{}, nil
+	}
+	x := k.encipher(delta, ciphertext, true)
+	var zeros byte
+	for _, b := range x[len(x)-tau:] {
+		zeros |= b
+	}
+	if zeros != 0 {
+		return nil, errAEZAuth
+	}
+	return x[:len(x)-tau], nil
+}
+
+// block returns the first block of b
+func block(b []byte) aezBlock {
+	var x aezBlock
+	copy(x[:], b[:aezBlockSize])
+	return x
+}
+
+// pad10 returns the block of b, shorter than a block, padded with 10*
+func pad10(b []byte) aezBlock {
+	var x aezBlock
+	copy(x[:], b)
+	x[len(b)] = 0x80
+	return x
+}
+
+func xorBlock(dst *aezBlock, src aezBlock) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
+
+// gfDouble multiplies b by 2 in GF(2^128) of AEZ
+func gfDouble(b aezBlock) aezBlock {
+	var d aezBlock
+	for i := 0; i < aezBlockSize-1; i++ {
+		d[i] = b[i]<<1 | b[i+1]>>7
+	}
+	d[aezBlockSize-1] = b[aezBlockSize-1] << 1
+	if b[0]&0x80 != 0 {
+		d[aezBlockSize-1] ^= 0x87
+	}
+	return d
+}
+
+// gfMulBlock multiplies b by the small integer x in GF(2^128) of AEZ
+func gfMulBlock(x int, b aezBlock) aezBlock {
+	var p aezBlock
+	for ; x > 0; x >>= 1 {
+		if x&1 != 0 {
+			xorBlock(&p, b)
+		}
+		b = gfDouble(b)
+	}
+	return p
+}
+
+// getBits returns the n bits of src from bit offset off, left aligned
+func getBits(src []byte, off, n int) aezBlock {
+	var b aezBlock
+	for i := 0; i < n; i++ {
+		if src[(off+i)/8]&(0x80>>((off+i)%8)) != 0 {
+			setBit(&b, i)
+		}
+	}
+	return b
+}
+
+// putBits writes the first n bits of b to dst from bit offset off
+func putBits(dst []byte, off int, b aezBlock, n int) {
+	for i := 0; i < n; i++ {
+		if b[i/8]&(0x80>>(i%8)) != 0 {
+			dst[(off+i)/8] |= 0x80 >> ((off + i) % 8)
+		}
+	}
+}
+
+func setBit(b *aezBlock, i int) {
+	b[i/8] |= 0x80 >> (i % 8)
+}
+
+// clearBitsFrom clears every bit of b from bit i on
+func clearBitsFrom(b *aezBlock, i int) {
+	if i%8 != 0 {
+		b[i/8] &= 0xff << (8 - i%8)
+		i += 8 - i%8
+	}
+	for ; i < 8*aezBlockSize; i += 8 {
+		b[i/8] = 0
+	}
+}
+
+// aesRound is one full AES round: SubBytes, ShiftRows, MixColumns and
+// AddRoundKey. The state is column major as in FIPS-197.
+func aesRound(x aezBlock, rk *aezBlock) aezBlock {
+	var s aezBlock
+	for c := 0; c < 4; c++ {
+		for r := 0; r < 4; r++ {
+			s[4*c+r] = aesSbox[x[4*((c+r)%4)+r]]
+		}
+	}
+	var out aezBlock
+	for c := 0; c < 4; c++ {
+		a0, a1, a2, a3 := s[4*c], s[4*c+1], s[4*c+2], s[4*c+3]
+		out[4*c] = gfMul2(a0) ^ gfMul2(a1) ^ a1 ^ a2 ^ a3
+		out[4*c+1] = a0 ^ gfMul2(a1) ^ gfMul2(a2) ^ a2 ^ a3
+		out[4*c+2] = a0 ^ a1 ^ gfMul2(a2) ^ gfMul2(a3) ^ a3
+		out[4*c+3] = gfMul2(a0) ^ a0 ^ a1 ^ a2 ^ gfMul2(a3)
+	}
+	xorBlock(&out, *rk)
+	return out
+}
+
+// gfMul2 multiplies by 2 in GF(256) of AES
+func gfMul2(a byte) byte {
+	if a&0x80 != 0 {
+		return a<<1 ^ 0x1b
+	}
+	return a << 1
+}
+
+// aesSbox is the AES S-box
+var aesSbox = [256]byte{
+	0x63, 0x7c, 0x77, 0x7b, 0xf2, 0x6b, 0x6f, 0xc5, 0x30, 0x01, 0x67, 0x2b, 0xfe, 0xd7, 0xab, 0x76,
+	0xca, 0x82, 0xc9, 0x7d, 0xfa, 0x59, 0x47, 0xf0, 0xad, 0xd4, 0xa2, 0xaf, 0x9c, 0xa4, 0x72, 0xc0,
+	0xb7, 0xfd, 0x93, 0x26, 0x36, 0x3f, 0xf7, 0xcc, 0x34, 0xa5, 0xe5, 0xf1, 0x71, 0xd8, 0x31, 0x15,
+	0x04, 0xc7, 0x23, 0xc3, 0x18, 0x96, 0x05, 0x9a, 0x07, 0x12, 0x80, 0xe2, 0xeb, 0x27, 0xb2, 0x75,
+	0x09, 0x83, 0x2c, 0x1a, 0x1b, 0x6e, 0x5a, 0xa0, 0x52, 0x3b, 0xd6, 0xb3, 0x29, 0xe3, 0x2f, 0x84,
+	0x53, 0xd1, 0x00, 0xed, 0x20, 0xfc, 0xb1, 0x5b, 0x6a, 0xcb, 0xbe, 0x39, 0x4a, 0x4c, 0x58, 0xcf,
+	0xd0, 0xef, 0xaa, 0xfb, 0x43, 0x4d, 0x33, 0x85, 0x45, 0xf9, 0x02, 0x7f, 0x50, 0x3c, 0x9f, 0xa8,
+	0x51, 0xa3, 0x40, 0x8f, 0x92, 0x9d, 0x38, 0xf5, 0xbc, 0xb6, 0xda, 0x21, 0x10, 0xff, 0xf3, 0xd2,
+	0xcd, 0x0c, 0x13, 0xec, 0x5f, 0x97, 0x44, 0x17, 0xc4, 0xa7, 0x7e, 0x3d, 0x64, 0x5d, 0x19, 0x73,
+	0x60, 0x81, 0x4f, 0xdc, 0x22, 0x2a, 0x90, 0x88, 0x46, 0xee, 0xb8, 0x14, 0xde, 0x5e, 0x0b, 0xdb,
+	0xe0, 0x32, 0x3a, 0x0a, 0x49, 0x06, 0x24, 0x5c, 0xc2, 0xd3, 0xac, 0x62, 0x91, 0x95, 0xe4, 0x79,
+	0xe7, 0xc8, 0x37, 0x6d, 0x8d, 0xd5, 0x4e, 0xa9, 0x6c, 0x56, 0xf4, 0xea, 0x65, 0x7a, 0xae, 0x08,
+	0xba, 0x78, 0x25, 0x2e, 0x1c, 0xa6, 0xb4, 0xc6, 0xe8, 0xdd, 0x74, 0x1f, 0x4b, 0xbd, 0x8b, 0x8a,
+	0x70, 0x3e, 0xb5, 0x66, 0x48, 0x03, 0xf6, 0x0e, 0x61, 0x35, 0x57, 0xb9, 0x86, 0xc1, 0x1d, 0x9e,
+	0xe1, 0xf8, 0x98, 0x11, 0x69, 0xd9, 0x8e, 0x94, 0x9b, 0x1e, 0x87, 0xe9, 0xce, 0x55, 0x28, 0xdf,
+	0x8c, 0xa1, 0x89, 0x0d, 0xbf, 0xe6, 0x42, 0x68, 0x41, 0x99, 0x2d, 0x0f, 0xb0, 0x54, 0xbb, 0x16,
+}
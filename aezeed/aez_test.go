@@ -0,0 +1,133 @@
+package aezeed
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The vectors of testdata are those of github.com/Yawning/aez, generated
+// with the AEZ v5 reference code
+
+// readAEZVectors decodes the vectors of testdata/name into vectors
+func readAEZVectors(t *testing.T, name string, vectors interface{}) {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, vectors))
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestAEZExtract(t *testing.T) {
+	var vectors []struct {
+		A string `json:"a"`
+		B string `json:"b"`
+	}
+	readAEZVectors(t, "extract.json", &vectors)
+	for i, v := range vectors {
+		k := newAEZKey(decodeHex(t, v.A))
+		extracted := append(append(k.i[:], k.j[:]...), k.l[:]...)
+		assert.Equal(t, v.B, hex.EncodeToString(extracted), i)
+	}
+}
+
+func TestAEZHash(t *testing.T) {
+	var vectors []struct {
+		K    string   `json:"k"`
+		Tau  int      `json:"tau"`
+		Data []string `json:"data"`
+		V    string   `json:"v"`
+	}
+	readAEZVectors(t, "hash.json", &vectors)
+	for i, v := range vectors {
+		var data [][]byte
+		for _, d := range v.Data {
+			data = append(data, decodeHex(t, d))
+		}
+		var nonce []byte
+		if len(data) > 0 {
+			nonce, data = data[0], data[1:]
+		}
+		delta := newAEZKey(decodeHex(t, v.K)).hash(v.Tau, nonce, data)
+		assert.Equal(t, v.V, hex.EncodeToString(delta[:]), i)
+	}
+}
+
+func TestAEZPRF(t *testing.T) {
+	var vectors []struct {
+		K     string `json:"k"`
+		Delta string `json:"delta"`
+		Tau   int    `json:"tau"`
+		R     string `json:"R"`
+	}
+	readAEZVectors(t, "prf.json", &vectors)
+	for i, v := range vectors {
+		var delta aezBlock
+		copy(delta[:], decodeHex(t, v.Delta))
+		r := newAEZKey(decodeHex(t, v.K)).prf(delta, v.Tau)
+		assert.Equal(t, v.R, hex.EncodeToString(r), i)
+	}
+}
+
+func TestAEZEncrypt(t *testing.T) {
+	for _, name := range []string{"encrypt.json", "encrypt_no_ad.json", "encrypt_33_byte_ad.json", "encrypt_16_byte_key.json"} {
+		var vectors []struct {
+			K     string   `json:"k"`
+			Nonce string   `json:"nonce"`
+			Data  []string `json:"data"`
+			Tau   int      `json:"tau"`
+			M     string   `json:"m"`
+			C     string   `json:"c"`
+		}
+		readAEZVectors(t, name, &vectors)
+		for i, v := range vectors {
+			key, nonce := decodeHex(t, v.K), decodeHex(t, v.Nonce)
+			var ad [][]byte
+			for _, d := range v.Data {
+				ad = append(ad, decodeHex(t, d))
+			}
+			c := aezEncrypt(key, nonce, ad, v.Tau, decodeHex(t, v.M))
+			require.Equal(t, v.C, hex.EncodeToString(c), "%s %d", name, i)
+
+			m, err := aezDecrypt(key, nonce, ad, v.Tau, c)
+			require.NoError(t, err, "%s %d", name, i)
+			assert.Equal(t, v.M, hex.EncodeToString(m), "%s %d", name, i)
+
+			if v.Tau > 0 {
+				c[len(c)-1] ^= 0x01
+				_, err = aezDecrypt(key, nonce, ad, v.Tau, c)
+				assert.Equal(t, errAEZAuth, err, "%s %d", name, i)
+			}
+		}
+	}
+}
+
+func TestAEZ(t *testing.T) {
+	key := []byte("aez test key")
+	ad := [][]byte{[]byte("additional data")}
+	for n := 1; n < 4*aezMinCoreSize; n++ {
+		plaintext := bytes.Repeat([]byte{byte(n)}, n)
+		ciphertext := aezEncrypt(key, nil, ad, CipherTextExpansion, plaintext)
+		assert.Len(t, ciphertext, n+CipherTextExpansion)
+
+		decrypted, err := aezDecrypt(key, nil, ad, CipherTextExpansion, ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, decrypted)
+
+		ciphertext[0] ^= 0x01
+		_, err = aezDecrypt(key, nil, ad, CipherTextExpansion, ciphertext)
+		assert.Equal(t, errAEZAuth, err)
+	}
+	_, err := aezDecrypt(key, nil, ad, CipherTextExpansion, make([]byte, CipherTextExpansion-1))
+	assert.Equal(t, errAEZAuth, err)
+}
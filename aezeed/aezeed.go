@@ -0,0 +1,257 @@
+// Package aezeed encodes and decodes the aezeed cipher seed mnemonics of lnd.
+//
+// An aezeed mnemonic is 24 words of the english bip39 word list encoding
+// 33 bytes: version || ciphertext || salt || checksum. The ciphertext is
+// the AEZ encryption of version || birthday || entropy under a scrypt key
+// of the passphrase and salt, the checksum is the CRC-32C of everything
+// before it. The entropy is the seed of the bip32 master key of the wallet.
+//
+// Ref https://github.com/lightningnetwork/lnd/tree/master/aezeed
+package aezeed
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/mearaj/bips/bip32"
+	"github.com/mearaj/bips/bip39/wordlists"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// CipherSeedVersion is the only known version of the cipher seed
+	CipherSeedVersion = 0
+	// InternalVersion is the only known version of the enciphered plaintext
+	InternalVersion = 0
+	// EntropySize is the size of the entropy, the seed of the bip32 master key
+	EntropySize = 16
+	// DecipheredCipherSeedSize is the size of version || birthday || entropy
+	DecipheredCipherSeedSize = 1 + 2 + EntropySize
+	// CipherTextExpansion is the number of bytes AEZ adds to the plaintext
+	CipherTextExpansion = 4
+	// NumMnemonicWords is the number of words of a mnemonic
+	NumMnemonicWords = 24
+	// EncipheredCipherSeedSize is the number of bytes encoded by a mnemonic
+	EncipheredCipherSeedSize = 33
+	// CipherTextSize is the size of version || birthday || entropy once
+	// enciphered with 4 bytes of ciphertext expansion
+	CipherTextSize = 23
+	// SaltSize is the size of the salt of the scrypt key derivation
+	SaltSize = 5
+	// ChecksumSize is the size of the CRC-32C checksum
+	ChecksumSize = 4
+
+	// DefaultPassphrase is the passphrase lnd uses when none is set
+	DefaultPassphrase = "aezeed"
+
+	bitsPerWord    = 11
+	scryptKeyLen   = 32
+	saltOffset     = 1 + CipherTextSize
+	checksumOffset = saltOffset + SaltSize
+)
+
+var (
+	ErrInvalidMnemonicLength = errors.New("aezeed mnemonic must be 24 words")
+	ErrUnknownMnemonicWord   = errors.New("word is not in the english bip39 word list")
+	ErrIncorrectVersion      = errors.New("unknown aezeed version")
+	ErrIncorrectChecksum     = errors.New("aezeed checksum mismatch")
+	ErrInvalidPassphrase     = errors.New("invalid aezeed passphrase")
+	ErrInvalidBirthday       = errors.New("aezeed birthday out of range")
+)
+
+// BitcoinGenesisDate is the time of the bitcoin genesis block, the day from
+// which the birthday of a cipher seed is counted
+var BitcoinGenesisDate = time.Unix(1231006505, 0)
+
+// the scrypt parameters of version 0, variables so that tests can lower them
+// as lnd's own tests do
+var (
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+)
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+	wordMap  = map[string]int{}
+)
+
+func init() {
+	for i, w := range wordlists.English {
+		wordMap[w] = i
+	}
+}
+
+// CipherSeed is the plaintext of an aezeed mnemonic
+type CipherSeed struct {
+	InternalVersion byte
+	// Birthday is the number of days between the bitcoin genesis block and
+	// the creation of the seed
+	Birthday uint16
+	Entropy  [EntropySize]byte
+	Salt     [SaltSize]byte
+}
+
+// NewCipherSeed returns a cipher seed of entropy born at birthday, with a
+// random salt
+func NewCipherSeed(entropy [EntropySize]byte, birthday time.Time) (*CipherSeed, error) {
+	days := birthday.Sub(BitcoinGenesisDate) / (24 * time.Hour)
+	if birthday.Before(BitcoinGenesisDate) || days > 0xffff {
+		return nil, fmt.Errorf("%w: `%v`", ErrInvalidBirthday, birthday)
+	}
+	seed := &CipherSeed{
+		InternalVersion: InternalVersion,
+		Birthday:        uint16(days),
+		Entropy:         entropy,
+	}
+	if _, err := rand.Read(seed.Salt[:]); err != nil {
+		return nil, err
+	}
+	return seed, nil
+}
+
+// BirthdayTime returns the birthday of the seed as a time
+func (c *CipherSeed) BirthdayTime() time.Time {
+	return BitcoinGenesisDate.Add(time.Duration(c.Birthday) * 24 * time.Hour)
+}
+
+// MasterKey returns the bip32 master key of the wallet of the seed
+func (c *CipherSeed) MasterKey() (*bip32.Key, error) {
+	return bip32.NewMasterKey(c.Entropy[:])
+}
+
+// Encipher encrypts the seed under the passphrase, the DefaultPassphrase
+// if empty
+func (c *CipherSeed) Encipher(passphrase []byte) (*EncipheredSeed, error) {
+	key, err := deriveKey(passphrase, c.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := make([]byte, DecipheredCipherSeedSize)
+	plaintext[0] = c.InternalVersion
+	binary.BigEndian.PutUint16(plaintext[1:3], c.Birthday)
+	copy(plaintext[3:], c.Entropy[:])
+	cipherText := aezEncrypt(key, nil, [][]byte{additionalData(c.Salt)}, CipherTextExpansion, plaintext)
+	seed := &EncipheredSeed{Version: CipherSeedVersion, Salt: c.Salt}
+	copy(seed.CipherText[:], cipherText)
+	return seed, nil
+}
+
+// Mnemonic returns the 24 words of the seed encrypted under the passphrase
+func (c *CipherSeed) Mnemonic(passphrase []byte) (string, error) {
+	seed, err := c.Encipher(passphrase)
+	if err != nil {
+		return "", err
+	}
+	return seed.Mnemonic(), nil
+}
+
+// DecodeMnemonic parses the mnemonic and decrypts it with the passphrase,
+// the DefaultPassphrase if empty
+func DecodeMnemonic(mnemonic string, passphrase []byte) (*CipherSeed, error) {
+	seed, err := ParseMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return seed.Decipher(passphrase)
+}
+
+// deriveKey derives the AEZ key of the passphrase and salt with scrypt
+func deriveKey(passphrase []byte, salt [SaltSize]byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		passphrase = []byte(DefaultPassphrase)
+	}
+	return scrypt.Key(passphrase, salt[:], scryptN, scryptR, scryptP, scryptKeyLen)
+}
+
+// additionalData is the AEZ associated data version || salt
+func additionalData(salt [SaltSize]byte) []byte {
+	return append([]byte{CipherSeedVersion}, salt[:]...)
+}
+
+// EncipheredSeed is the decoded content of an aezeed mnemonic
+type EncipheredSeed struct {
+	Version    byte
+	CipherText [CipherTextSize]byte
+	Salt       [SaltSize]byte
+}
+
+// ParseMnemonic decodes the mnemonic and verifies its version and checksum
+func ParseMnemonic(mnemonic string) (*EncipheredSeed, error) {
+	words := strings.Fields(mnemonic)
+	if len(words) != NumMnemonicWords {
+		return nil, ErrInvalidMnemonicLength
+	}
+	data := new(big.Int)
+	for _, w := range words {
+		idx, ok := wordMap[w]
+		if !ok {
+			return nil, fmt.Errorf("%w: `%v`", ErrUnknownMnemonicWord, w)
+		}
+		data.Lsh(data, bitsPerWord)
+		data.Or(data, big.NewInt(int64(idx)))
+	}
+	bs := data.FillBytes(make([]byte, EncipheredCipherSeedSize))
+	if bs[0] != CipherSeedVersion {
+		return nil, ErrIncorrectVersion
+	}
+	checksum := crc32.Checksum(bs[:checksumOffset], crcTable)
+	if checksum != binary.BigEndian.Uint32(bs[checksumOffset:]) {
+		return nil, ErrIncorrectChecksum
+	}
+	seed := &EncipheredSeed{Version: bs[0]}
+	copy(seed.CipherText[:], bs[1:saltOffset])
+	copy(seed.Salt[:], bs[saltOffset:checksumOffset])
+	return seed, nil
+}
+
+// Decipher decrypts the seed with the passphrase, the DefaultPassphrase if
+// empty. A wrong passphrase fails with ErrInvalidPassphrase.
+func (s *EncipheredSeed) Decipher(passphrase []byte) (*CipherSeed, error) {
+	key, err := deriveKey(passphrase, s.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aezDecrypt(key, nil, [][]byte{additionalData(s.Salt)}, CipherTextExpansion, s.CipherText[:])
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+	if plaintext[0] != InternalVersion {
+		return nil, ErrIncorrectVersion
+	}
+	seed := &CipherSeed{
+		InternalVersion: plaintext[0],
+		Birthday:        binary.BigEndian.Uint16(plaintext[1:3]),
+		Salt:            s.Salt,
+	}
+	copy(seed.Entropy[:], plaintext[3:])
+	return seed, nil
+}
+
+// Bytes returns the 33 bytes encoded by the mnemonic, checksum included
+func (s *EncipheredSeed) Bytes() []byte {
+	bs := make([]byte, EncipheredCipherSeedSize)
+	bs[0] = s.Version
+	copy(bs[1:saltOffset], s.CipherText[:])
+	copy(bs[saltOffset:checksumOffset], s.Salt[:])
+	binary.BigEndian.PutUint32(bs[checksumOffset:], crc32.Checksum(bs[:checksumOffset], crcTable))
+	return bs
+}
+
+// Mnemonic encodes the enciphered seed into its 24 words
+func (s *EncipheredSeed) Mnemonic() string {
+	data := new(big.Int).SetBytes(s.Bytes())
+	mask := big.NewInt(1<<bitsPerWord - 1)
+	words := make([]string, NumMnemonicWords)
+	for i := NumMnemonicWords - 1; i >= 0; i-- {
+		words[i] = wordlists.English[new(big.Int).And(data, mask).Int64()]
+		data.Rsh(data, bitsPerWord)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,131 @@
+package aezeed
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mearaj/bips/bip32"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Ref https://github.com/lightningnetwork/lnd/blob/master/aezeed/cipherseed_test.go
+var testMnemonics = []string{
+	"ability liquid travel stem barely drastic pact cupboard apple thrive morning oak feature tissue couch old math inform success suggest drink motion know royal",
+	"able tree stool crush transfer cloud cross three profit outside hen citizen plate ride require leg siren drum success suggest drink require fiscal upgrade",
+}
+
+func TestParseMnemonic(t *testing.T) {
+	for _, m := range testMnemonics {
+		seed, err := ParseMnemonic(m)
+		assert.NoError(t, err)
+		assert.Equal(t, byte(CipherSeedVersion), seed.Version)
+		assert.Equal(t, "salt1", string(seed.Salt[:]))
+		assert.Equal(t, m, seed.Mnemonic())
+	}
+	seed, _ := ParseMnemonic(testMnemonics[0])
+	assert.Equal(t, "304b9e6aa12884a7a9ae0abc263fcbe543c58c3cd0890e", hex.EncodeToString(seed.CipherText[:]))
+}
+
+func TestParseMnemonicErrors(t *testing.T) {
+	words := strings.Fields(testMnemonics[0])
+
+	_, err := ParseMnemonic(strings.Join(words[:23], " "))
+	assert.Equal(t, ErrInvalidMnemonicLength, err)
+
+	invalid := append([]string{"lightning"}, words[1:]...)
+	_, err = ParseMnemonic(strings.Join(invalid, " "))
+	assert.ErrorIs(t, err, ErrUnknownMnemonicWord)
+
+	swapped := append([]string{}, words...)
+	swapped[5], swapped[6] = swapped[6], swapped[5]
+	_, err = ParseMnemonic(strings.Join(swapped, " "))
+	assert.Equal(t, ErrIncorrectChecksum, err)
+
+	versioned := append([]string{"zoo"}, words[1:]...)
+	_, err = ParseMnemonic(strings.Join(versioned, " "))
+	assert.Equal(t, ErrIncorrectVersion, err)
+}
+
+var testEntropy = [EntropySize]byte{
+	0x81, 0xb6, 0x37, 0xd8, 0x63, 0x59, 0xe6, 0x96,
+	0x0d, 0xe7, 0x95, 0xe4, 0x1e, 0x0b, 0x4c, 0xfd,
+}
+
+// TestLNDVectors deciphers the mnemonics of the lnd test vectors, which lnd
+// enciphers with the scrypt N lowered to 16
+func TestLNDVectors(t *testing.T) {
+	defer func(n int) { scryptN = n }(scryptN)
+	scryptN = 16
+
+	passphrases := []string{"", "!very_safe_55345_password*"}
+	birthdays := []uint16{0, 3365}
+	for i, m := range testMnemonics {
+		seed, err := DecodeMnemonic(m, []byte(passphrases[i]))
+		require.NoError(t, err)
+		assert.Equal(t, testEntropy, seed.Entropy)
+		assert.Equal(t, birthdays[i], seed.Birthday)
+
+		seed.Salt = [SaltSize]byte{'s', 'a', 'l', 't', '1'}
+		mnemonic, err := seed.Mnemonic([]byte(passphrases[i]))
+		require.NoError(t, err)
+		assert.Equal(t, m, mnemonic)
+
+		_, err = DecodeMnemonic(m, []byte("wrong passphrase"))
+		assert.Equal(t, ErrInvalidPassphrase, err)
+	}
+}
+
+// TestScryptParams enciphers the first lnd test vector with the scrypt
+// parameters of real seeds, the mnemonic computed with github.com/Yawning/aez
+func TestScryptParams(t *testing.T) {
+	seed := &CipherSeed{Entropy: testEntropy, Salt: [SaltSize]byte{'s', 'a', 'l', 't', '1'}}
+	mnemonic, err := seed.Mnemonic(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "above judge emerge veteran reform crunch system all snap please shoulder vault hurt city quarter cover enlist swear success suggest drink wagon enrich body", mnemonic)
+	decoded, err := DecodeMnemonic(mnemonic, nil)
+	require.NoError(t, err)
+	assert.Equal(t, seed, decoded)
+}
+
+func TestCipherSeed(t *testing.T) {
+	birthday := time.Unix(1521799345, 0)
+	seed, err := NewCipherSeed(testEntropy, birthday)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(3365), seed.Birthday)
+	assert.Equal(t, "2018-03-22", seed.BirthdayTime().UTC().Format(time.DateOnly))
+
+	for _, passphrase := range []string{"", "!very_safe_55345_password*"} {
+		mnemonic, err := seed.Mnemonic([]byte(passphrase))
+		require.NoError(t, err)
+		decoded, err := DecodeMnemonic(mnemonic, []byte(passphrase))
+		require.NoError(t, err)
+		assert.Equal(t, seed, decoded)
+
+		_, err = DecodeMnemonic(mnemonic, []byte("wrong passphrase"))
+		assert.Equal(t, ErrInvalidPassphrase, err)
+	}
+
+	// an empty passphrase is the default passphrase
+	mnemonic, err := seed.Mnemonic(nil)
+	require.NoError(t, err)
+	decoded, err := DecodeMnemonic(mnemonic, []byte(DefaultPassphrase))
+	require.NoError(t, err)
+	assert.Equal(t, seed, decoded)
+
+	_, err = NewCipherSeed(testEntropy, BitcoinGenesisDate.Add(-time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidBirthday)
+	_, err = NewCipherSeed(testEntropy, BitcoinGenesisDate.Add(0x10000*24*time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidBirthday)
+}
+
+func TestCipherSeedMasterKey(t *testing.T) {
+	seed := &CipherSeed{Entropy: testEntropy}
+	key, err := seed.MasterKey()
+	require.NoError(t, err)
+	expected, err := bip32.NewMasterKey(testEntropy[:])
+	require.NoError(t, err)
+	assert.Equal(t, expected.String(), key.String())
+}
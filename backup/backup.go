@@ -0,0 +1,320 @@
+// Package backup reads and writes password encrypted backups of a
+// mnemonic, a seed or an extended private key with their metadata.
+//
+// A backup is a fixed header followed by the sealed JSON encoding of a
+// Backup. The header holds the format version, the key derivation function
+// with its parameters, the cipher, the salt and the nonce, and it is
+// authenticated as additional data, so any modified byte of the file makes
+// Read fail, with ErrDecryption once the header itself is valid.
+//
+//	magic "BIPSBAK" | version | kdf | 3 x uint32 kdf params | cipher |
+//	salt (16) | nonce (12 or 24) | ciphertext and tag
+package backup
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Magic starts every backup
+const Magic = "BIPSBAK"
+
+// Version is the format version written by Write
+const Version byte = 1
+
+// KDF is the function deriving the encryption key from the password
+type KDF byte
+
+const (
+	KDFArgon2id KDF = iota + 1
+	KDFScrypt
+)
+
+// Cipher is the AEAD encrypting the backup
+type Cipher byte
+
+const (
+	CipherXChaCha20Poly1305 Cipher = iota + 1
+	CipherAESGCM
+)
+
+const (
+	saltSize = 16
+	keySize  = 32
+	// paramsSize is the size of the three kdf parameters
+	paramsSize = 12
+	headerSize = len(Magic) + 1 + 1 + paramsSize + 1 + saltSize
+	// the kdf parameters come from the header, which is only authenticated
+	// once the key is derived, so maxArgon2Time, maxArgon2Memory in KiB,
+	// maxScryptMemory in bytes and maxScryptP bound the work a backup can
+	// ask for to about 1 GiB and 10 passes
+	maxArgon2Time   = 10
+	maxArgon2Memory = 1 << 20
+	maxScryptMemory = 1 << 30
+	maxScryptP      = 10
+)
+
+var (
+	ErrInvalidMagic      = errors.New("not a bips backup")
+	ErrUnknownVersion    = errors.New("unknown backup version")
+	ErrUnknownKDF        = errors.New("unknown key derivation function")
+	ErrInvalidKDFParams  = errors.New("invalid key derivation parameters")
+	ErrUnknownCipher     = errors.New("unknown cipher")
+	ErrTruncated         = errors.New("truncated backup")
+	ErrDecryption        = errors.New("wrong password or tampered backup")
+	ErrEmptyBackup       = errors.New("backup holds no mnemonic, seed or xprv")
+	ErrEmptyPassword     = errors.New("password must not be empty")
+	ErrInvalidBackupData = errors.New("invalid backup data")
+)
+
+// Backup is the secret and its metadata, at least one of Mnemonic, Seed
+// and XPrv has to be set
+type Backup struct {
+	Mnemonic string `json:"mnemonic,omitempty"`
+	// PassphraseHint helps remembering the mnemonic passphrase, never the passphrase itself
+	PassphraseHint string `json:"passphraseHint,omitempty"`
+	Seed           []byte `json:"seed,omitempty"`
+	XPrv           string `json:"xprv,omitempty"`
+	// Language is the language of the mnemonic word list
+	Language string `json:"language,omitempty"`
+	// Paths are the derivation paths in use, ex m/44'/60'/0'/0/0
+	Paths     []string  `json:"paths,omitempty"`
+	Label     string    `json:"label,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Options selects the key derivation function, its parameters and the cipher,
+// zero values are replaced by the defaults
+type Options struct {
+	KDF    KDF
+	Cipher Cipher
+	// Argon2id time, memory in KiB and threads
+	Argon2Time    uint32
+	Argon2Memory  uint32
+	Argon2Threads uint32
+	// Scrypt cost parameters
+	ScryptN uint32
+	ScryptR uint32
+	ScryptP uint32
+}
+
+// DefaultOptions is argon2id with the RFC 9106 second recommended
+// parameters and XChaCha20-Poly1305
+var DefaultOptions = Options{
+	KDF:           KDFArgon2id,
+	Cipher:        CipherXChaCha20Poly1305,
+	Argon2Time:    3,
+	Argon2Memory:  64 << 10,
+	Argon2Threads: 4,
+	ScryptN:       1 << 17,
+	ScryptR:       8,
+	ScryptP:       1,
+}
+
+type header struct {
+	version byte
+	kdf     KDF
+	params  [3]uint32
+	cipher  Cipher
+	salt    [saltSize]byte
+}
+
+// Encrypt returns the encrypted backup of b under password
+func Encrypt(b *Backup, password []byte, opts *Options) ([]byte, error) {
+	if len(password) == 0 {
+		return nil, ErrEmptyPassword
+	}
+	if b.Mnemonic == "" && len(b.Seed) == 0 && b.XPrv == "" {
+		return nil, ErrEmptyBackup
+	}
+	h := newHeader(opts)
+	if err := h.validate(); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(h.salt[:]); err != nil {
+		return nil, err
+	}
+	aead, err := h.aead(password)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	plaintext, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+	out := append(h.bytes(), nonce...)
+	return aead.Seal(out, nonce, plaintext, out), nil
+}
+
+// Decrypt authenticates and decrypts an encrypted backup
+func Decrypt(data, password []byte) (*Backup, error) {
+	h, err := parseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := h.aead(password)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < headerSize+aead.NonceSize()+aead.Overhead() {
+		return nil, ErrTruncated
+	}
+	ad := data[:headerSize+aead.NonceSize()]
+	nonce := ad[headerSize:]
+	plaintext, err := aead.Open(nil, nonce, data[len(ad):], ad)
+	if err != nil {
+		return nil, ErrDecryption
+	}
+	var b Backup
+	if err = json.Unmarshal(plaintext, &b); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBackupData, err)
+	}
+	return &b, nil
+}
+
+// Write encrypts b under password and writes it to w
+func Write(w io.Writer, b *Backup, password []byte, opts *Options) error {
+	data, err := Encrypt(b, password, opts)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Read reads an encrypted backup from r and decrypts it
+func Read(r io.Reader, password []byte) (*Backup, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return Decrypt(data, password)
+}
+
+func newHeader(opts *Options) *header {
+	o := DefaultOptions
+	if opts != nil {
+		if opts.KDF != 0 {
+			o.KDF = opts.KDF
+		}
+		if opts.Cipher != 0 {
+			o.Cipher = opts.Cipher
+		}
+		if opts.Argon2Time != 0 {
+			o.Argon2Time = opts.Argon2Time
+		}
+		if opts.Argon2Memory != 0 {
+			o.Argon2Memory = opts.Argon2Memory
+		}
+		if opts.Argon2Threads != 0 {
+			o.Argon2Threads = opts.Argon2Threads
+		}
+		if opts.ScryptN != 0 {
+			o.ScryptN = opts.ScryptN
+		}
+		if opts.ScryptR != 0 {
+			o.ScryptR = opts.ScryptR
+		}
+		if opts.ScryptP != 0 {
+			o.ScryptP = opts.ScryptP
+		}
+	}
+	h := &header{version: Version, kdf: o.KDF, cipher: o.Cipher}
+	switch o.KDF {
+	case KDFArgon2id:
+		h.params = [3]uint32{o.Argon2Time, o.Argon2Memory, o.Argon2Threads}
+	case KDFScrypt:
+		h.params = [3]uint32{o.ScryptN, o.ScryptR, o.ScryptP}
+	}
+	return h
+}
+
+func parseHeader(data []byte) (*header, error) {
+	if len(data) < len(Magic) || !bytes.Equal(data[:len(Magic)], []byte(Magic)) {
+		return nil, ErrInvalidMagic
+	}
+	if len(data) < headerSize {
+		return nil, ErrTruncated
+	}
+	data = data[len(Magic):]
+	h := &header{version: data[0], kdf: KDF(data[1])}
+	if h.version != Version {
+		return nil, fmt.Errorf("%w: `%v`", ErrUnknownVersion, h.version)
+	}
+	for i := range h.params {
+		h.params[i] = binary.BigEndian.Uint32(data[2+4*i:])
+	}
+	h.cipher = Cipher(data[2+paramsSize])
+	copy(h.salt[:], data[3+paramsSize:])
+	return h, h.validate()
+}
+
+func (h *header) bytes() []byte {
+	out := append([]byte(Magic), h.version, byte(h.kdf))
+	for _, p := range h.params {
+		out = binary.BigEndian.AppendUint32(out, p)
+	}
+	out = append(out, byte(h.cipher))
+	return append(out, h.salt[:]...)
+}
+
+func (h *header) validate() error {
+	p := h.params
+	switch h.kdf {
+	case KDFArgon2id:
+		if p[0] == 0 || p[0] > maxArgon2Time || p[1] < 8*p[2] || p[1] > maxArgon2Memory || p[2] == 0 || p[2] > 255 {
+			return ErrInvalidKDFParams
+		}
+	case KDFScrypt:
+		// scrypt uses 128 * N * r bytes
+		if p[0] < 2 || p[0]&(p[0]-1) != 0 || p[1] == 0 || p[2] == 0 || p[2] > maxScryptP ||
+			128*uint64(p[0])*uint64(p[1]) > maxScryptMemory {
+			return ErrInvalidKDFParams
+		}
+	default:
+		return fmt.Errorf("%w: `%v`", ErrUnknownKDF, h.kdf)
+	}
+	if h.cipher != CipherXChaCha20Poly1305 && h.cipher != CipherAESGCM {
+		return fmt.Errorf("%w: `%v`", ErrUnknownCipher, h.cipher)
+	}
+	return nil
+}
+
+func (h *header) key(password []byte) ([]byte, error) {
+	p := h.params
+	if h.kdf == KDFScrypt {
+		return scrypt.Key(password, h.salt[:], int(p[0]), int(p[1]), int(p[2]), keySize)
+	}
+	return argon2.IDKey(password, h.salt[:], p[0], p[1], uint8(p[2]), keySize), nil
+}
+
+func (h *header) aead(password []byte) (cipher.AEAD, error) {
+	key, err := h.key(password)
+	if err != nil {
+		return nil, err
+	}
+	if h.cipher == CipherAESGCM {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	}
+	return chacha20poly1305.NewX(key)
+}
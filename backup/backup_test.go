@@ -0,0 +1,117 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastOptions keep the tests quick, they are far too weak for real backups
+var fastOptions = []Options{
+	{KDF: KDFArgon2id, Cipher: CipherXChaCha20Poly1305, Argon2Time: 1, Argon2Memory: 64, Argon2Threads: 1},
+	{KDF: KDFArgon2id, Cipher: CipherAESGCM, Argon2Time: 1, Argon2Memory: 64, Argon2Threads: 1},
+	{KDF: KDFScrypt, Cipher: CipherXChaCha20Poly1305, ScryptN: 1 << 4},
+	{KDF: KDFScrypt, Cipher: CipherAESGCM, ScryptN: 1 << 4},
+}
+
+func testBackup() *Backup {
+	return &Backup{
+		Mnemonic:       "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		PassphraseHint: "the usual one",
+		XPrv:           "xprv9s21ZrQH143K3GJpoapnV8SFfukcVBSfeCficPSGfubmSFDxo1kuHnLisriDvSnRRuL2Qrg5ggqHKNVpxR86QEC8w35uxmGoggxtQTPvfUu",
+		Language:       "english",
+		Paths:          []string{"m/44'/60'/0'/0/0", "m/84'/0'/0'/0/0"},
+		CreatedAt:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	password := []byte("correct horse battery staple")
+	for _, opts := range fastOptions {
+		opts := opts
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, testBackup(), password, &opts))
+		assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte(Magic)))
+		assert.NotContains(t, buf.String(), "abandon")
+
+		b, err := Read(bytes.NewReader(buf.Bytes()), password)
+		require.NoError(t, err)
+		assert.Equal(t, testBackup(), b)
+
+		_, err = Read(bytes.NewReader(buf.Bytes()), []byte("wrong"))
+		assert.Equal(t, ErrDecryption, err)
+	}
+}
+
+func TestTampering(t *testing.T) {
+	password := []byte("password")
+	data, err := Encrypt(&Backup{Seed: []byte{1, 2, 3}}, password, &fastOptions[0])
+	require.NoError(t, err)
+	paramsStart := len(Magic) + 2
+	for i := range data {
+		// tampered kdf parameters can ask for too much work to be tested,
+		// any other byte must fail validation or authentication
+		if i >= paramsStart && i < paramsStart+paramsSize {
+			continue
+		}
+		tampered := append([]byte(nil), data...)
+		tampered[i] ^= 1
+		_, err = Decrypt(tampered, password)
+		assert.Error(t, err, "byte %d", i)
+	}
+	_, err = Decrypt(data[:len(data)-1], password)
+	assert.Equal(t, ErrDecryption, err)
+	_, err = Decrypt(data[:headerSize], password)
+	assert.Equal(t, ErrTruncated, err)
+	_, err = Decrypt([]byte("not a backup"), password)
+	assert.Equal(t, ErrInvalidMagic, err)
+}
+
+// TestOversizedHeader checks that parseHeader, which Decrypt runs before
+// deriving the key, rejects the kdf parameters above the bounds
+func TestOversizedHeader(t *testing.T) {
+	password := []byte("password")
+	for _, v := range []struct {
+		opts   Options
+		params [3]uint32
+	}{
+		{fastOptions[0], [3]uint32{maxArgon2Time + 1, 64, 1}},
+		{fastOptions[0], [3]uint32{1, maxArgon2Memory + 1, 1}},
+		{fastOptions[0], [3]uint32{1, 4 << 20, 4}},
+		{fastOptions[2], [3]uint32{1 << 21, 8, 1}},
+		{fastOptions[2], [3]uint32{1 << 4, 1 << 27, 1}},
+		{fastOptions[2], [3]uint32{1 << 4, 8, maxScryptP + 1}},
+	} {
+		data, err := Encrypt(&Backup{Seed: []byte{1, 2, 3}}, password, &v.opts)
+		require.NoError(t, err)
+		for i, p := range v.params {
+			binary.BigEndian.PutUint32(data[len(Magic)+2+4*i:], p)
+		}
+		_, err = parseHeader(data)
+		assert.Equal(t, ErrInvalidKDFParams, err, v.params)
+		_, err = Decrypt(data, password)
+		assert.Equal(t, ErrInvalidKDFParams, err, v.params)
+	}
+	// the bounds themselves are accepted
+	h := &header{kdf: KDFArgon2id, params: [3]uint32{maxArgon2Time, maxArgon2Memory, 4}, cipher: CipherAESGCM}
+	assert.NoError(t, h.validate())
+	h = &header{kdf: KDFScrypt, params: [3]uint32{1 << 20, 8, maxScryptP}, cipher: CipherAESGCM}
+	assert.NoError(t, h.validate())
+}
+
+func TestInvalid(t *testing.T) {
+	_, err := Encrypt(&Backup{Label: "empty"}, []byte("password"), &fastOptions[0])
+	assert.Equal(t, ErrEmptyBackup, err)
+	_, err = Encrypt(testBackup(), nil, nil)
+	assert.Equal(t, ErrEmptyPassword, err)
+	_, err = Encrypt(testBackup(), []byte("password"), &Options{KDF: KDFScrypt, ScryptN: 1000})
+	assert.Equal(t, ErrInvalidKDFParams, err)
+	_, err = Encrypt(testBackup(), []byte("password"), &Options{KDF: 9})
+	assert.ErrorIs(t, err, ErrUnknownKDF)
+	_, err = Encrypt(testBackup(), []byte("password"), &Options{Cipher: 9})
+	assert.ErrorIs(t, err, ErrUnknownCipher)
+}
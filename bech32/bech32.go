@@ -0,0 +1,261 @@
+// Package bech32 is the Golang implementation of the BIP173 bech32 and
+// BIP350 bech32m encodings and of the segregated witness addresses built
+// on them.
+//
+// The official BIP173 and BIP350 specs can be found at
+// https://github.com/bitcoin/bips/blob/master/bip-0173.mediawiki
+// https://github.com/bitcoin/bips/blob/master/bip-0350.mediawiki
+package bech32
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Charset is the bech32 character set, the index of a character is its value
+	Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	// MaxLength is the maximum length of a bech32 string
+	MaxLength = 90
+
+	checksumLength = 6
+	bech32Const    = 1
+	bech32mConst   = 0x2bc830a3
+)
+
+var (
+	ErrInvalidLength    = errors.New("invalid bech32 string length")
+	ErrMixedCase        = errors.New("bech32 string must not mix upper and lower case")
+	ErrMissingSeparator = errors.New("missing bech32 separator 1")
+	ErrInvalidHRP       = errors.New("invalid bech32 human readable part")
+	ErrInvalidCharacter = errors.New("invalid bech32 character")
+	ErrInvalidChecksum  = errors.New("invalid bech32 checksum")
+	ErrInvalidPadding   = errors.New("invalid bech32 padding")
+	ErrInvalidDataValue = errors.New("bech32 data value must be less than 32")
+)
+
+var charsetRev = func() [128]int8 {
+	var rev [128]int8
+	for i := range rev {
+		rev[i] = -1
+	}
+	for i, c := range Charset {
+		rev[c] = int8(i)
+	}
+	return rev
+}()
+
+var generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+func polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i, g := range generator {
+			if b>>uint(i)&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	return chk
+}
+
+func hrpExpand(hrp string) []byte {
+	values := make([]byte, 0, 2*len(hrp)+1)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]>>5)
+	}
+	values = append(values, 0)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]&31)
+	}
+	return values
+}
+
+func createChecksum(hrp string, data []byte, constant uint32) []byte {
+	values := append(hrpExpand(hrp), data...)
+	values = append(values, make([]byte, checksumLength)...)
+	mod := polymod(values) ^ constant
+	checksum := make([]byte, checksumLength)
+	for i := range checksum {
+		checksum[i] = byte(mod>>uint(5*(checksumLength-1-i))) & 31
+	}
+	return checksum
+}
+
+// Encode returns the bech32 string of hrp and the 5 bit values of data
+func Encode(hrp string, data []byte) (string, error) {
+	return encode(hrp, data, bech32Const)
+}
+
+// Decode returns the human readable part and the 5 bit values of the
+// bech32 string s, checksum excluded
+func Decode(s string) (string, []byte, error) {
+	hrp, data, constant, err := decode(s)
+	if err != nil {
+		return "", nil, err
+	}
+	if constant != bech32Const {
+		return "", nil, ErrInvalidChecksum
+	}
+	return hrp, data, nil
+}
+
+// EncodeM returns the bech32m string of hrp and the 5 bit values of data
+func EncodeM(hrp string, data []byte) (string, error) {
+	return encode(hrp, data, bech32mConst)
+}
+
+// DecodeM is Decode for bech32m strings
+func DecodeM(s string) (string, []byte, error) {
+	hrp, data, constant, err := decode(s)
+	if err != nil {
+		return "", nil, err
+	}
+	if constant != bech32mConst {
+		return "", nil, ErrInvalidChecksum
+	}
+	return hrp, data, nil
+}
+
+func encode(hrp string, data []byte, constant uint32) (string, error) {
+	if err := validateHRP(hrp); err != nil {
+		return "", err
+	}
+	if hrp != strings.ToLower(hrp) {
+		return "", ErrMixedCase
+	}
+	if len(hrp)+1+len(data)+checksumLength > MaxLength {
+		return "", ErrInvalidLength
+	}
+	var b strings.Builder
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, v := range data {
+		if v > 31 {
+			return "", ErrInvalidDataValue
+		}
+		b.WriteByte(Charset[v])
+	}
+	for _, v := range createChecksum(hrp, data, constant) {
+		b.WriteByte(Charset[v])
+	}
+	return b.String(), nil
+}
+
+// decode returns the polymod residue of s as constant, telling which
+// checksum variant s uses
+func decode(s string) (string, []byte, uint32, error) {
+	if len(s) > MaxLength {
+		return "", nil, 0, ErrInvalidLength
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 33 || s[i] > 126 {
+			return "", nil, 0, fmt.Errorf("%w: `%c` at position %d", ErrInvalidCharacter, s[i], i)
+		}
+	}
+	lower := strings.ToLower(s)
+	if s != lower && s != strings.ToUpper(s) {
+		return "", nil, 0, ErrMixedCase
+	}
+	sep := strings.LastIndexByte(lower, '1')
+	if sep < 0 {
+		return "", nil, 0, ErrMissingSeparator
+	}
+	if sep+1+checksumLength > len(lower) {
+		return "", nil, 0, ErrInvalidLength
+	}
+	hrp := lower[:sep]
+	if err := validateHRP(hrp); err != nil {
+		return "", nil, 0, err
+	}
+	data := make([]byte, 0, len(lower)-sep-1)
+	for i := sep + 1; i < len(lower); i++ {
+		c := lower[i]
+		if charsetRev[c] < 0 {
+			return "", nil, 0, fmt.Errorf("%w: `%c` at position %d", ErrInvalidCharacter, c, i)
+		}
+		data = append(data, byte(charsetRev[c]))
+	}
+	constant := polymod(append(hrpExpand(hrp), data...))
+	return hrp, data[:len(data)-checksumLength], constant, nil
+}
+
+func validateHRP(hrp string) error {
+	if len(hrp) < 1 || len(hrp) > 83 {
+		return ErrInvalidHRP
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return fmt.Errorf("%w: `%c` at position %d", ErrInvalidCharacter, hrp[i], i)
+		}
+	}
+	return nil
+}
+
+// ConvertBits regroups data of fromBits wide values into toBits wide values.
+// With pad the last value is padded with zeros, without it the leftover
+// bits must be zeros and fewer than fromBits.
+func ConvertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var acc, bits uint
+	maxV := uint(1)<<toBits - 1
+	out := make([]byte, 0, (uint(len(data))*fromBits+toBits-1)/toBits)
+	for _, v := range data {
+		if uint(v)>>fromBits != 0 {
+			return nil, ErrInvalidDataValue
+		}
+		acc = acc<<fromBits | uint(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxV))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(toBits-bits)&maxV))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxV != 0 {
+		return nil, ErrInvalidPadding
+	}
+	return out, nil
+}
+
+// LocateErrors returns the positions in s of the characters whose single
+// substitution makes the bech32 or bech32m checksum valid, the likely typo
+// of a string failing with ErrInvalidChecksum. The code corrects a single
+// error unambiguously, nil is returned when no single substitution fixes s.
+func LocateErrors(s string) []int {
+	lower := strings.ToLower(s)
+	sep := strings.LastIndexByte(lower, '1')
+	if sep < 1 || len(lower) > MaxLength || validateHRP(lower[:sep]) != nil {
+		return nil
+	}
+	data := make([]byte, 0, len(lower)-sep-1)
+	for i := sep + 1; i < len(lower); i++ {
+		if lower[i] >= 128 || charsetRev[lower[i]] < 0 {
+			return nil
+		}
+		data = append(data, byte(charsetRev[lower[i]]))
+	}
+	values := append(hrpExpand(lower[:sep]), data...)
+	offset := len(values) - len(data)
+	var positions []int
+	for i := range data {
+		original := values[offset+i]
+		for v := byte(0); v < 32; v++ {
+			if v == original {
+				continue
+			}
+			values[offset+i] = v
+			if mod := polymod(values); mod == bech32Const || mod == bech32mConst {
+				positions = append(positions, sep+1+i)
+				break
+			}
+		}
+		values[offset+i] = original
+	}
+	return positions
+}
@@ -0,0 +1,169 @@
+package bech32
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0173.mediawiki#test-vectors
+func TestValidBech32(t *testing.T) {
+	for _, s := range []string{
+		"A12UEL5L",
+		"a12uel5l",
+		"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		"11qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqc8247j",
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+		"?1ezyfcl",
+	} {
+		hrp, data, err := Decode(s)
+		if !assert.NoError(t, err, s) {
+			continue
+		}
+		encoded, err := Encode(hrp, data)
+		assert.NoError(t, err, s)
+		assert.Equal(t, strings.ToLower(s), encoded)
+	}
+}
+
+func TestInvalidBech32(t *testing.T) {
+	for s, expected := range map[string]error{
+		"\x201nwldj5": ErrInvalidCharacter,
+		"\x7f1axkwrx": ErrInvalidCharacter,
+		"an84characterslonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1569pvx": ErrInvalidLength,
+		"pzry9x0s0muk":    ErrMissingSeparator,
+		"1pzry9x0s0muk":   ErrInvalidHRP,
+		"x1b4n0q5v":       ErrInvalidCharacter,
+		"li1dgmt3":        ErrInvalidLength,
+		"de1lg7wt\xff":    ErrInvalidCharacter,
+		"A1G7SGD8":        ErrInvalidChecksum,
+		"10a06t8":         ErrInvalidHRP,
+		"1qzzfhee":        ErrInvalidHRP,
+		"a12UEL5L":        ErrMixedCase,
+		"abcdef1qpzrz9x8": ErrInvalidChecksum,
+	} {
+		_, _, err := Decode(s)
+		assert.ErrorIs(t, err, expected, s)
+	}
+}
+
+func TestSegWitAddress(t *testing.T) {
+	vectors := []struct {
+		address      string
+		hrp          string
+		scriptPubKey string
+	}{
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", HRPBitcoin, "0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", HRPBitcoinTestnet, "00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"tb1qqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesrxh6hy", HRPBitcoinTestnet, "0020000000c4a5cad46221b2a187905e5266362b99d5e91c6ce24d165dab93e86433"},
+	}
+	for _, v := range vectors {
+		version, program, err := DecodeSegWitAddress(v.hrp, v.address)
+		if !assert.NoError(t, err, v.address) {
+			continue
+		}
+		script := append([]byte{version, byte(len(program))}, program...)
+		assert.Equal(t, v.scriptPubKey, hex.EncodeToString(script))
+		addr, err := EncodeSegWitAddress(v.hrp, version, program)
+		assert.NoError(t, err)
+		assert.Equal(t, strings.ToLower(v.address), addr)
+	}
+}
+
+func TestInvalidSegWitAddress(t *testing.T) {
+	for addr, expected := range map[string]error{
+		"tc1qw508d6qejxtdg4y5r3zarvary0c5xw7kg3g4ty": ErrHRPMismatch,
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5": ErrInvalidChecksum,
+		"BC13W508D6QEJXTDG4Y5R3ZARVARY0C5XW7KN40WF2": ErrInvalidWitnessVersion,
+		"bc1rw5uspcuh":                         ErrInvalidProgramLength,
+		"BC1QR508D6QEJXTDG4Y5R3ZARVARYV98GJ9P": ErrInvalidProgramLength,
+		"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sL5k7": ErrMixedCase,
+		"bc1zw508d6qejxtdg4y5r3zarvaryvqyzf3du":                          ErrInvalidPadding,
+		"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3pjxtptv": ErrInvalidPadding,
+		"bc1gmk9yu": ErrInvalidProgramLength,
+		"bc10w508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kw5rljs90": ErrInvalidProgramLength,
+	} {
+		hrp := HRPBitcoin
+		if strings.HasPrefix(strings.ToLower(addr), "tb") {
+			hrp = HRPBitcoinTestnet
+		}
+		_, _, err := DecodeSegWitAddress(hrp, addr)
+		assert.ErrorIs(t, err, expected, addr)
+	}
+}
+
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0350.mediawiki#test-vectors
+func TestValidBech32m(t *testing.T) {
+	for _, s := range []string{
+		"A1LQFN3A",
+		"a1lqfn3a",
+		"an83characterlonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber11sg7hg6",
+		"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx",
+		"11llllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllludsr8",
+		"split1checkupstagehandshakeupstreamerranterredcaperredlc445v",
+		"?1v759aa",
+	} {
+		hrp, data, err := DecodeM(s)
+		if !assert.NoError(t, err, s) {
+			continue
+		}
+		encoded, err := EncodeM(hrp, data)
+		assert.NoError(t, err, s)
+		assert.Equal(t, strings.ToLower(s), encoded)
+		_, _, err = Decode(s)
+		assert.Equal(t, ErrInvalidChecksum, err, s)
+	}
+}
+
+func TestSegWitAddressBech32m(t *testing.T) {
+	vectors := []struct {
+		address      string
+		hrp          string
+		scriptPubKey string
+	}{
+		{"bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y", HRPBitcoin, "5128751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"BC1SW50QGDZ25J", HRPBitcoin, "6002751e"},
+		{"bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs", HRPBitcoin, "5210751e76e8199196d454941c45d1b3a323"},
+		{"tb1pqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesf3hn0c", HRPBitcoinTestnet, "5120000000c4a5cad46221b2a187905e5266362b99d5e91c6ce24d165dab93e86433"},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", HRPBitcoin, "512079be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+	}
+	for _, v := range vectors {
+		version, program, err := DecodeSegWitAddress(v.hrp, v.address)
+		if !assert.NoError(t, err, v.address) {
+			continue
+		}
+		// OP_1 to OP_16 are 0x51 to 0x60
+		script := append([]byte{version + 0x50, byte(len(program))}, program...)
+		assert.Equal(t, v.scriptPubKey, hex.EncodeToString(script))
+		addr, err := EncodeSegWitAddress(v.hrp, version, program)
+		assert.NoError(t, err)
+		assert.Equal(t, strings.ToLower(v.address), addr)
+	}
+
+	for addr, expected := range map[string]error{
+		"tc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq5zuyut": ErrHRPMismatch,
+		"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd": ErrInvalidChecksum,
+		"BC1S0XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ54WELL": ErrInvalidChecksum,
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh":                     ErrInvalidChecksum,
+		"bc1p38j9r5y49hruaue7wxjce0updqjuyyx0kh56v8s25huc6995vvpql3jow4": ErrInvalidCharacter,
+		"BC130XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ7ZWS8R": ErrInvalidWitnessVersion,
+		"bc1pw5dgrnzv": ErrInvalidProgramLength,
+		"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v8n0nx0muaewav253zgeav": ErrInvalidProgramLength,
+		"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v07qwwzcrf":             ErrInvalidPadding,
+	} {
+		_, _, err := DecodeSegWitAddress(HRPBitcoin, addr)
+		assert.ErrorIs(t, err, expected, addr)
+	}
+	for addr, expected := range map[string]error{
+		"tb1z0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqglt7rf": ErrInvalidChecksum,
+		"tb1q0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq24jc47": ErrInvalidChecksum,
+		"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq47Zagq": ErrMixedCase,
+		"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vpggkg4j": ErrInvalidPadding,
+	} {
+		_, _, err := DecodeSegWitAddress(HRPBitcoinTestnet, addr)
+		assert.ErrorIs(t, err, expected, addr)
+	}
+}
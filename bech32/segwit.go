@@ -0,0 +1,86 @@
+package bech32
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Human readable parts of segwit addresses
+const (
+	HRPBitcoin            = "bc"
+	HRPBitcoinTestnet     = "tb"
+	HRPBitcoinRegtest     = "bcrt"
+	HRPLitecoin           = "ltc"
+	HRPLitecoinTestnet    = "tltc"
+	HRPGroestlcoin        = "grs"
+	HRPGroestlcoinTestnet = "tgrs"
+	HRPSyscoin            = "sys"
+)
+
+var (
+	ErrInvalidWitnessVersion = errors.New("witness version must be between 0 and 16")
+	ErrInvalidProgramLength  = errors.New("invalid witness program length")
+	ErrHRPMismatch           = errors.New("address human readable part mismatch")
+)
+
+// EncodeSegWitAddress returns the segwit address of the witness program,
+// bech32 for witness version 0 and bech32m for the later versions
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0173.mediawiki#segwit-address-format
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0350.mediawiki#addresses-for-segregated-witness-outputs
+func EncodeSegWitAddress(hrp string, version byte, program []byte) (string, error) {
+	if err := validateWitness(version, program); err != nil {
+		return "", err
+	}
+	data, err := ConvertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return encode(hrp, append([]byte{version}, data...), witnessConst(version))
+}
+
+// DecodeSegWitAddress returns the witness version and program of the segwit
+// address addr, whose human readable part must be hrp
+func DecodeSegWitAddress(hrp, addr string) (byte, []byte, error) {
+	gotHRP, data, constant, err := decode(addr)
+	if err != nil {
+		return 0, nil, err
+	}
+	if gotHRP != hrp {
+		return 0, nil, fmt.Errorf("%w: `%v`", ErrHRPMismatch, gotHRP)
+	}
+	if len(data) < 1 {
+		return 0, nil, ErrInvalidProgramLength
+	}
+	if constant != bech32Const && constant != bech32mConst {
+		return 0, nil, ErrInvalidChecksum
+	}
+	version := data[0]
+	program, err := ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err = validateWitness(version, program); err != nil {
+		return 0, nil, err
+	}
+	if constant != witnessConst(version) {
+		return 0, nil, fmt.Errorf("%w: wrong bech32 variant for witness version %d", ErrInvalidChecksum, version)
+	}
+	return version, program, nil
+}
+
+func witnessConst(version byte) uint32 {
+	if version == 0 {
+		return bech32Const
+	}
+	return bech32mConst
+}
+
+func validateWitness(version byte, program []byte) error {
+	if version > 16 {
+		return ErrInvalidWitnessVersion
+	}
+	if len(program) < 2 || len(program) > 40 || version == 0 && len(program) != 20 && len(program) != 32 {
+		return ErrInvalidProgramLength
+	}
+	return nil
+}
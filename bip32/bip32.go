@@ -136,7 +136,7 @@ func (key *Key) NewChildKey(childIdx uint32) (Key, error) {
 	childKey.SetVersion(vs)
 	// Bip32 CKDpriv
 	if key.IsPrivate() {
-		fingerprint, err := HashRipeMD160onSha256(
+		fingerprint, err := versionBytesOf(vsVal).HashOf(
 			publicKeyForPrivateKey(key[PvtKeyStartIndex:PubKeyEndIndex]),
 		)
 		if err != nil {
@@ -160,7 +160,7 @@ func (key *Key) NewChildKey(childIdx uint32) (Key, error) {
 		if err != nil {
 			return childKey, err
 		}
-		fingerprint, err := HashRipeMD160onSha256(key[PubKeyStartIndex:])
+		fingerprint, err := versionBytesOf(vsVal).HashOf(key[PubKeyStartIndex:])
 		if err != nil {
 			return childKey, err
 		}
@@ -243,7 +243,17 @@ func (key *Key) PublicKeyHex() string {
 
 // Serialize a Key to a slice of 82 byte
 // (78 byte(s) + 4 byte(s) of checksum)
+// The checksum is the one of the version of the key, the standard
+// doublesha256 one for a version shared by several coins. Groestlcoin
+// shares all its versions with bitcoin, so its keys need SerializeWith, ex
+// with the VersionBytesOfPath of their path, or
+// netparams.Groestlcoin.EncodeKey for their Groestl-512 checksum.
 func (key *Key) Serialize() ([]byte, error) {
+	return key.SerializeWith(versionBytesOf(key.GetVersion()))
+}
+
+// SerializeWith serializes the Key with the checksum of the VersionBytes h
+func (key *Key) SerializeWith(h VersionBytes) ([]byte, error) {
 	isEmpty := true
 	for _, v := range key {
 		if v != 0 {
@@ -254,15 +264,15 @@ func (key *Key) Serialize() ([]byte, error) {
 	if isEmpty {
 		return nil, ErrEmptyKey
 	}
-	// Append the standard doublesha256 ChecksumDblSha256
-	serializedKey, err := AddChecksumDblSha256ToBytes(key[:])
+	checksum, err := h.ChecksumOf(key[:])
 	if err != nil {
 		return nil, err
 	}
-	return serializedKey, nil
+	return append(key[:], checksum...), nil
 }
 
-// B58Serialize encodes the Key in the standard Bitcoin base58 encoding
+// B58Serialize encodes the Key in the standard Bitcoin base58 encoding, with
+// the checksum of Serialize
 func (key *Key) B58Serialize() string {
 	serializedKey, err := key.Serialize()
 	if err != nil {
@@ -271,8 +281,18 @@ func (key *Key) B58Serialize() string {
 	return base58.Encode(serializedKey)
 }
 
+// B58SerializeWith encodes the Key in base58 with the checksum of the
+// VersionBytes h
+func (key *Key) B58SerializeWith(h VersionBytes) string {
+	serializedKey, err := key.SerializeWith(h)
+	if err != nil {
+		return ""
+	}
+	return base58.Encode(serializedKey)
+}
+
 // String encodes the Key in the standard Bitcoin base58 encoding
-// Set Version before calling String()
+// Set Version before calling String(), see Serialize for Groestlcoin keys
 func (key *Key) String() string {
 	return key.B58Serialize()
 }
@@ -290,7 +310,30 @@ func (key *Key) IsValid() bool {
 }
 
 // Deserialize a byte slice into a Key
+// The checksum may be the one of any of the coins registered with the
+// version of the key, ex the doublesha256 or the Groestlcoin one of xprv
 func Deserialize(data []byte) (Key, error) {
+	if len(data) != 82 {
+		return DeserializeWith(data, VersionBytes{})
+	}
+	hdBytesArr := versionBytesSliceOf(binary.BigEndian.Uint32(data[VersionStartIndex:VersionEndIndex]))
+	if len(hdBytesArr) == 0 {
+		hdBytesArr = []VersionBytes{{}}
+	}
+	var key Key
+	var err error
+	for _, h := range hdBytesArr {
+		key, err = DeserializeWith(data, h)
+		if err != ErrInvalidChecksum {
+			return key, err
+		}
+	}
+	return key, err
+}
+
+// DeserializeWith deserializes a byte slice into a Key verifying the
+// checksum of the VersionBytes h
+func DeserializeWith(data []byte, h VersionBytes) (Key, error) {
 	if len(data) != 82 {
 		if len(data) < 82 {
 			exData := make([]byte, 82-len(data))
@@ -298,8 +341,7 @@ func Deserialize(data []byte) (Key, error) {
 		}
 		return Key(data[:78]), ErrSerializedKeyWrongSize
 	}
-	// validate ChecksumDblSha256
-	cs1, err := ChecksumDblSha256(data[0 : len(data)-4])
+	cs1, err := h.ChecksumOf(data[0 : len(data)-4])
 	if err != nil {
 		return Key(data[:78]), err
 	}
@@ -384,6 +426,13 @@ func B58Deserialize(data string) (Key, error) {
 	return Deserialize(b)
 }
 
+// B58DeserializeWith deserializes a Key encoded in base58 encoding verifying
+// the checksum of the VersionBytes h
+func B58DeserializeWith(data string, h VersionBytes) (Key, error) {
+	b := base58.Decode(data)
+	return DeserializeWith(b, h)
+}
+
 // NewSeed returns a cryptographically secure seed
 func NewSeed() ([]byte, error) {
 	// Well that easy, just make go read 256 random bytes into a slice
@@ -256,3 +256,81 @@ func assertKeySerialization(t *testing.T, key Key, knownBase58 string) {
 	assert.NoError(t, err)
 	assert.Equal(t, key, unserializedBase58)
 }
+
+// Ref https://github.com/decred/dcrd/blob/master/hdkeychain/extendedkey_test.go
+func TestDecredVectors(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	key, err := NewMasterKey(seed)
+	assert.NoError(t, err)
+	key.SetVersion(Decreddprvdpub.PvtKeyFlagBytes())
+	assertKeySerialization(t, *key, "dprv3hCznBesA6jBtmoyVFPfyMSZ1qYZ3WdjdebquvkEfmRfxC9VFEFi2YDaJqHnx7uGe75eGSa3Mn3oHK11hBW7KZUrPxwbCPBmuCi1nwm182s")
+	pubKey := key.PublicKeyExtended()
+	assertKeySerialization(t, pubKey, "dpubZ9169KDAEUnyoBhjjmT2VaEodr6pUTDoqCEAeqgbfr2JfkB88BbK77jbTYbcYXb2FVz7DKBdW4P618yd51MwF8DjKVopSbS7Lkgi6bowX5w")
+
+	// the fingerprint of the child is the BLAKE-256 based hash160 of the parent
+	child, err := key.NewChildKey(FirstHardenedChild)
+	assert.NoError(t, err)
+	assertKeySerialization(t, child, "dprv3kUQDBztdyjKuwnaL3hfKYpT7W6X2huYH5d61YSWFBebSYwEBHAXJkCpQ7rvMAxPzKqxVCGLvBqWvGxXjAyMJsV1XwKkfnQCM9KctC8k8bk")
+	childPub := child.PublicKeyExtended()
+	assertKeySerialization(t, childPub, "dpubZCGVaKZBiMo7pMgLaZm1qmchjWenTeVcUdFQkTNsFGFEA6xs4EW8PKiqYqP7HBAitt9Hw16VQkQ1tjsZQSHNWFc6bEK6bLqrbco24FzBTY4")
+
+	// the double SHA-256 checksum of bitcoin doesn't validate Decred keys
+	bs, err := key.Serialize()
+	assert.NoError(t, err)
+	sum, _ := ChecksumDblSha256(bs[:78])
+	_, err = Deserialize(append(bs[:78:78], sum...))
+	assert.Equal(t, ErrInvalidChecksum, err)
+}
+
+// Groestlcoin shares the xprv and xpub versions of bitcoin but its checksum is
+// the double Groestl-512 one
+func TestGroestlcoinVectors(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	key, err := NewMasterKey(seed)
+	assert.NoError(t, err)
+	for k, expected := range map[Key]string{
+		*key:                    "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBsoWepH",
+		key.PublicKeyExtended(): "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGoPLHV",
+	} {
+		s := k.B58SerializeWith(Groestlcoinxprvxpub)
+		assert.Equal(t, expected, s)
+		decoded, err := B58DeserializeWith(s, Groestlcoinxprvxpub)
+		assert.NoError(t, err)
+		assert.Equal(t, k, decoded)
+		// Deserialize accepts the checksums of all the coins of the version
+		decoded, err = B58Deserialize(s)
+		assert.NoError(t, err)
+		assert.Equal(t, k, decoded)
+		_, err = B58DeserializeWith(s, Bitcoinxprvxpub)
+		assert.Equal(t, ErrInvalidChecksum, err)
+	}
+	// the bitcoin serialization stays the default of the shared version
+	assert.Equal(t, "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi", key.String())
+}
+
+func TestVersionBytesOfPath(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	key, err := NewMasterKey(seed)
+	assert.NoError(t, err)
+	pub := key.PublicKeyExtended()
+	for path, coin := range map[Path]string{
+		"m/44'/17'/0'/0/0": "Groestlcoin",
+		"M/84'/17'/0'":     "Groestlcoin",
+		"m/44'/0'":         "Bitcoin",
+		"m/84'/0'/0'":      "Bitcoin",
+		"m/44'/1'/0'":      "Bitcoin Testnet",
+		"m/44'/60'/0'":     "",
+		"m":                "",
+	} {
+		for _, k := range []*Key{key, &pub} {
+			h := VersionBytesOfPath(path, k.GetVersion())
+			assert.Equal(t, coin, h.Coin, path)
+			if coin == "Groestlcoin" {
+				assert.Equal(t, k.B58SerializeWith(Groestlcoinxprvxpub), k.B58SerializeWith(h), path)
+			} else {
+				assert.Equal(t, k.String(), k.B58SerializeWith(h), path)
+			}
+		}
+	}
+	assert.Equal(t, Groestlcoinzprvzpub.PvtKeyFlag, VersionBytesOfPath("m/84'/17'/0'", Groestlcoinzprvzpub.PvtKeyFlag).PvtKeyFlag)
+}
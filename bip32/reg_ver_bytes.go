@@ -39,6 +39,35 @@ const (
 	P2WSH        AddrEncoding = "P2WSH"
 	P2WSHInP2SH  AddrEncoding = "P2WSHInP2SH"
 	P2PKT        AddrEncoding = "P2PKT"
+	P2TR         AddrEncoding = "P2TR"
+	// EVMHex is the 0x prefixed hex of the last 20 bytes of the keccak256
+	// of the public key, as used by Ethereum and the EVM chains
+	EVMHex AddrEncoding = "EVMHex"
+	// Cosmos is the bech32 of the RIPEMD160 of the SHA256 of the public key
+	// with the human readable part of a Cosmos SDK chain
+	Cosmos AddrEncoding = "Cosmos"
+	// CosmosValOper and CosmosValCons are the bech32 addresses of a Cosmos
+	// SDK validator operator and of its consensus node, ex cosmosvaloper1…
+	// and cosmosvalcons1…
+	CosmosValOper AddrEncoding = "CosmosValOper"
+	CosmosValCons AddrEncoding = "CosmosValCons"
+	// TronBase58 is the base58check of the 0x41 prefixed Ethereum style
+	// address of the key, as used by Tron
+	TronBase58 AddrEncoding = "TronBase58"
+	// XAddress is the XRP Ledger account id packed with an optional
+	// destination tag, the classic r… addresses being P2PKH
+	XAddress AddrEncoding = "XAddress"
+	// CashAddrP2PKH and CashAddrP2SH are the CashAddr forms of P2PKH and
+	// P2SH addresses, ex bitcoincash:q…
+	CashAddrP2PKH AddrEncoding = "CashAddrP2PKH"
+	CashAddrP2SH  AddrEncoding = "CashAddrP2SH"
+	// FilecoinSecp256k1 is the base32 of the BLAKE2b-160 of the uncompressed
+	// public key and its checksum, the f1… addresses of Filecoin
+	FilecoinSecp256k1 AddrEncoding = "FilecoinSecp256k1"
+	// EOSPubKey and EOSPubKeyK1 are the EOS… and PUB_K1_… public keys of
+	// EOS and the Antelope chains, which name accounts instead of hashing keys
+	EOSPubKey   AddrEncoding = "EOSPubKey"
+	EOSPubKeyK1 AddrEncoding = "EOSPubKeyK1"
 )
 
 type VersionBytes struct {
@@ -55,10 +84,83 @@ type VersionBytes struct {
 	// PubKeyPrefix is similar to PvKeyPrefix but deals with Public Keys
 	PubKeyPrefix  string
 	AddrEncodings []AddrEncoding
+	// HRP is the bech32 human readable part of the native segwit addresses
+	HRP string
+	// ScriptHashPrefix is the base58 version byte of the nested segwit addresses
+	ScriptHashPrefix byte
+	// Hash160 hashes the parent public key into the fingerprint of child keys,
+	// nil for HashRipeMD160onSha256
+	Hash160 func(data []byte) ([]byte, error)
+	// Checksum returns the 4 bytes checksum of serialized keys, nil for
+	// ChecksumDblSha256
+	Checksum func(data []byte) ([]byte, error)
 	// Path
 	Path Path
 }
 
+// HashOf hashes data with the Hash160 of h
+func (h VersionBytes) HashOf(data []byte) ([]byte, error) {
+	if h.Hash160 == nil {
+		return HashRipeMD160onSha256(data)
+	}
+	return h.Hash160(data)
+}
+
+// ChecksumOf returns the checksum of serialized keys of h
+func (h VersionBytes) ChecksumOf(data []byte) ([]byte, error) {
+	if h.Checksum == nil {
+		return ChecksumDblSha256(data)
+	}
+	sum, err := h.Checksum(data)
+	if err != nil {
+		return nil, err
+	}
+	return sum[:4], nil
+}
+
+// versionBytesOf returns the VersionBytes of the private or public key flag.
+// A flag shared by several coins, ex xprv, has the bitcoin hash functions.
+func versionBytesOf(flag uint32) VersionBytes {
+	hdBytesArr := versionBytesSliceOf(flag)
+	if len(hdBytesArr) == 1 {
+		return hdBytesArr[0]
+	}
+	return VersionBytes{}
+}
+
+// versionBytesSliceOf returns all the VersionBytes of the private or public
+// key flag
+func versionBytesSliceOf(flag uint32) []VersionBytes {
+	hdBytesArr, ok := PvtFlagToHDBytesSlice[flag]
+	if !ok {
+		hdBytesArr = PubFlagToHDBytesSlice[flag]
+	}
+	return hdBytesArr
+}
+
+// VersionBytesOfPath returns the VersionBytes registered for the purpose and
+// coin of the path p with the private or public key flag, ex
+// Groestlcoinxprvxpub for m/44'/17'/0' and xprv. A key of another flag
+// gets the hash functions of the first VersionBytes of the path, ex the
+// Groestlcoin checksum of an xprv at m/84'/17'/0', and a key of an
+// unregistered path those of its flag.
+func VersionBytesOfPath(p Path, flag uint32) VersionBytes {
+	levels := strings.Split(strings.ToLower(p.Formatted().String()), "/")
+	if len(levels) < 3 {
+		return versionBytesOf(flag)
+	}
+	hdBytesArr := PathToHDBytesSlice[strings.Join(levels[:3], "/")]
+	for _, h := range hdBytesArr {
+		if h.PvtKeyFlag == flag || h.PubKeyFlag == flag {
+			return h
+		}
+	}
+	if len(hdBytesArr) == 0 {
+		return versionBytesOf(flag)
+	}
+	return VersionBytes{Coin: hdBytesArr[0].Coin, Checksum: hdBytesArr[0].Checksum}
+}
+
 func (h VersionBytes) PvtKeyFlagBytes() [4]byte {
 	var b [4]byte
 	binary.BigEndian.PutUint32(b[:], h.PvtKeyFlag)
@@ -149,13 +251,14 @@ var (
 		Path:          "m/44'/0'",
 	}
 	Bitcoinyprvypub = VersionBytes{
-		Coin:          "Bitcoin",
-		PvtKeyFlag:    0x049d7878,
-		PvKeyPrefix:   "yprv",
-		PubKeyFlag:    0x049d7cb2,
-		PubKeyPrefix:  "ypub",
-		AddrEncodings: []AddrEncoding{P2WPKHInP2SH},
-		Path:          "m/49'/0'",
+		Coin:             "Bitcoin",
+		PvtKeyFlag:       0x049d7878,
+		PvKeyPrefix:      "yprv",
+		PubKeyFlag:       0x049d7cb2,
+		PubKeyPrefix:     "ypub",
+		AddrEncodings:    []AddrEncoding{P2WPKHInP2SH},
+		ScriptHashPrefix: 0x05,
+		Path:             "m/49'/0'",
 	}
 	Bitcoinzprvzpub = VersionBytes{
 		Coin:          "Bitcoin",
@@ -164,6 +267,7 @@ var (
 		PubKeyFlag:    0x04b24746,
 		PubKeyPrefix:  "zpub",
 		AddrEncodings: []AddrEncoding{P2WPKH},
+		HRP:           "bc",
 		Path:          "m/84'/0'",
 	}
 	BitcoinYprvYpub = VersionBytes{
@@ -182,6 +286,7 @@ var (
 		PubKeyFlag:    0x02aa7ed3,
 		PubKeyPrefix:  "Zpub",
 		AddrEncodings: []AddrEncoding{P2WSH},
+		HRP:           "bc",
 		Path:          "m/84'/0'",
 	}
 	Bitcointprvtpub = VersionBytes{
@@ -194,13 +299,14 @@ var (
 		Path:          "m/44'/1'",
 	}
 	Bitcoinuprvupub = VersionBytes{
-		Coin:          "Bitcoin Testnet",
-		PvtKeyFlag:    0x044a4e28,
-		PvKeyPrefix:   "uprv",
-		PubKeyFlag:    0x044a5262,
-		PubKeyPrefix:  "upub",
-		AddrEncodings: []AddrEncoding{P2WPKHInP2SH},
-		Path:          "m/49'/1'",
+		Coin:             "Bitcoin Testnet",
+		PvtKeyFlag:       0x044a4e28,
+		PvKeyPrefix:      "uprv",
+		PubKeyFlag:       0x044a5262,
+		PubKeyPrefix:     "upub",
+		AddrEncodings:    []AddrEncoding{P2WPKHInP2SH},
+		ScriptHashPrefix: 0xc4,
+		Path:             "m/49'/1'",
 	}
 	Bitcoinvprvvpub = VersionBytes{
 		Coin:          "Bitcoin Testnet",
@@ -209,6 +315,7 @@ var (
 		PubKeyFlag:    0x045f1cf6,
 		PubKeyPrefix:  "vpub",
 		AddrEncodings: []AddrEncoding{P2WPKH},
+		HRP:           "tb",
 		Path:          "m/84'/1'",
 	}
 	BitcoinUprvUpub = VersionBytes{
@@ -227,6 +334,7 @@ var (
 		PubKeyFlag:    0x02575483,
 		PubKeyPrefix:  "Vpub",
 		AddrEncodings: []AddrEncoding{P2WSH},
+		HRP:           "tb",
 		Path:          "m/84'/1'",
 	}
 	Groestlcoinxprvxpub = VersionBytes{
@@ -236,16 +344,19 @@ var (
 		PubKeyFlag:    0x0488b21e,
 		PubKeyPrefix:  "xpub",
 		AddrEncodings: []AddrEncoding{P2PKH, P2SH},
+		Checksum:      ChecksumDblGroestl512,
 		Path:          "m/44'/17'",
 	}
 	Groestlcoinyprvypub = VersionBytes{
-		Coin:          "Groestlcoin",
-		PvtKeyFlag:    0x049d7878,
-		PvKeyPrefix:   "yprv",
-		PubKeyFlag:    0x049d7cb2,
-		PubKeyPrefix:  "ypub",
-		AddrEncodings: []AddrEncoding{P2WPKHInP2SH},
-		Path:          "m/49'/17'",
+		Coin:             "Groestlcoin",
+		PvtKeyFlag:       0x049d7878,
+		PvKeyPrefix:      "yprv",
+		PubKeyFlag:       0x049d7cb2,
+		PubKeyPrefix:     "ypub",
+		AddrEncodings:    []AddrEncoding{P2WPKHInP2SH},
+		ScriptHashPrefix: 0x05,
+		Checksum:         ChecksumDblGroestl512,
+		Path:             "m/49'/17'",
 	}
 	Groestlcoinzprvzpub = VersionBytes{
 		Coin:          "Groestlcoin",
@@ -254,6 +365,8 @@ var (
 		PubKeyFlag:    0x04b24746,
 		PubKeyPrefix:  "zpub",
 		AddrEncodings: []AddrEncoding{P2WPKH},
+		HRP:           "grs",
+		Checksum:      ChecksumDblGroestl512,
 		Path:          "m/84'/17'",
 	}
 	GroestlcoinYprvYpub = VersionBytes{
@@ -263,6 +376,7 @@ var (
 		PubKeyFlag:    0x0295b43f,
 		PubKeyPrefix:  "Ypub",
 		AddrEncodings: []AddrEncoding{P2WSHInP2SH},
+		Checksum:      ChecksumDblGroestl512,
 		Path:          "m/84'/17'",
 	}
 	GroestlcoinZprvZpub = VersionBytes{
@@ -272,6 +386,8 @@ var (
 		PubKeyFlag:    0x02aa7ed3,
 		PubKeyPrefix:  "Zpub",
 		AddrEncodings: []AddrEncoding{P2WSH},
+		HRP:           "grs",
+		Checksum:      ChecksumDblGroestl512,
 		Path:          "m/84'/17'",
 	}
 	Groestlcointprvtpub = VersionBytes{
@@ -281,16 +397,19 @@ var (
 		PubKeyFlag:    0x043587cf,
 		PubKeyPrefix:  "tpub",
 		AddrEncodings: []AddrEncoding{P2PKH, P2SH},
+		Checksum:      ChecksumDblGroestl512,
 		Path:          "m/44'/1'",
 	}
 	Groestlcoinuprvupub = VersionBytes{
-		Coin:          "Groestlcoin Testnet",
-		PvtKeyFlag:    0x044a4e28,
-		PvKeyPrefix:   "uprv",
-		PubKeyFlag:    0x044a5262,
-		PubKeyPrefix:  "upub",
-		AddrEncodings: []AddrEncoding{P2WPKHInP2SH},
-		Path:          "m/49'/1'",
+		Coin:             "Groestlcoin Testnet",
+		PvtKeyFlag:       0x044a4e28,
+		PvKeyPrefix:      "uprv",
+		PubKeyFlag:       0x044a5262,
+		PubKeyPrefix:     "upub",
+		AddrEncodings:    []AddrEncoding{P2WPKHInP2SH},
+		ScriptHashPrefix: 0xc4,
+		Checksum:         ChecksumDblGroestl512,
+		Path:             "m/49'/1'",
 	}
 	Groestlcoinvprvvpub = VersionBytes{
 		Coin:          "Groestlcoin Testnet",
@@ -299,6 +418,8 @@ var (
 		PubKeyFlag:    0x045f1cf6,
 		PubKeyPrefix:  "vpub",
 		AddrEncodings: []AddrEncoding{P2WPKH},
+		HRP:           "tgrs",
+		Checksum:      ChecksumDblGroestl512,
 		Path:          "m/84'/1'",
 	}
 	GroestlcoinUprvUpub = VersionBytes{
@@ -308,6 +429,7 @@ var (
 		PubKeyFlag:    0x024289ef,
 		PubKeyPrefix:  "Upub",
 		AddrEncodings: []AddrEncoding{P2WSHInP2SH},
+		Checksum:      ChecksumDblGroestl512,
 		Path:          "m/84'/1'",
 	}
 	GroestlcoinVprvVpub = VersionBytes{
@@ -317,6 +439,8 @@ var (
 		PubKeyFlag:    0x02575483,
 		PubKeyPrefix:  "Vpub",
 		AddrEncodings: []AddrEncoding{P2WSH},
+		HRP:           "tgrs",
+		Checksum:      ChecksumDblGroestl512,
 		Path:          "m/84'/1'",
 	}
 	LitecoinLtpvLtub = VersionBytes{
@@ -329,13 +453,14 @@ var (
 		Path:          "m/44'/2'",
 	}
 	LitecoinMtpvMtub = VersionBytes{
-		Coin:          "Litecoin",
-		PvtKeyFlag:    0x01b26792,
-		PvKeyPrefix:   "Mtpv",
-		PubKeyFlag:    0x01b26ef6,
-		PubKeyPrefix:  "Mtub",
-		AddrEncodings: []AddrEncoding{P2WPKHInP2SH},
-		Path:          "m/49'/2'",
+		Coin:             "Litecoin",
+		PvtKeyFlag:       0x01b26792,
+		PvKeyPrefix:      "Mtpv",
+		PubKeyFlag:       0x01b26ef6,
+		PubKeyPrefix:     "Mtub",
+		AddrEncodings:    []AddrEncoding{P2WPKHInP2SH},
+		ScriptHashPrefix: 0x32,
+		Path:             "m/49'/2'",
 	}
 	Litecointtpvttub = VersionBytes{
 		Coin:          "Litecoin Testnet",
@@ -364,6 +489,30 @@ var (
 		AddrEncodings: []AddrEncoding{P2PKH, P2SH},
 		Path:          "m/44'/1'",
 	}
+	// Decred keys hash with BLAKE-256 instead of SHA-256
+	// Ref https://github.com/decred/dcrd/blob/master/chaincfg/mainnetparams.go
+	Decreddprvdpub = VersionBytes{
+		Coin:          "Decred",
+		PvtKeyFlag:    0x02fda4e8,
+		PvKeyPrefix:   "dprv",
+		PubKeyFlag:    0x02fda926,
+		PubKeyPrefix:  "dpub",
+		AddrEncodings: []AddrEncoding{P2PKH, P2SH},
+		Hash160:       HashRipeMD160onBlake256,
+		Checksum:      ChecksumDblBlake256,
+		Path:          "m/44'/42'",
+	}
+	Decredtprvtpub = VersionBytes{
+		Coin:          "Decred Testnet",
+		PvtKeyFlag:    0x04358397,
+		PvKeyPrefix:   "tprv",
+		PubKeyFlag:    0x043587d1,
+		PubKeyPrefix:  "tpub",
+		AddrEncodings: []AddrEncoding{P2PKH, P2SH},
+		Hash160:       HashRipeMD160onBlake256,
+		Checksum:      ChecksumDblBlake256,
+		Path:          "m/44'/1'",
+	}
 	Polispprvppub = VersionBytes{
 		Coin:          "Polis",
 		PvtKeyFlag:    0x03e25945,
@@ -380,6 +529,7 @@ var (
 		PubKeyFlag:    0x04b24746,
 		PubKeyPrefix:  "zpub",
 		AddrEncodings: []AddrEncoding{P2WPKH},
+		HRP:           "sys",
 		Path:          "m/84'/57'",
 	}
 	SyscoinZprvZpub = VersionBytes{
@@ -389,6 +539,7 @@ var (
 		PubKeyFlag:    0x02aa7ed3,
 		PubKeyPrefix:  "Zpub",
 		AddrEncodings: []AddrEncoding{P2WSH},
+		HRP:           "sys",
 		Path:          "m/84'/57'",
 	}
 	Vertcoinvtcpvtcv = VersionBytes{
@@ -408,7 +559,7 @@ var PathToHDBytesSlice = map[string][]VersionBytes{
 	"m/49'/0'": {Bitcoinyprvypub},
 	"m/84'/0'": {Bitcoinzprvzpub, BitcoinYprvYpub, BitcoinZprvZpub},
 	"m/44'/1'": {Bitcointprvtpub, Groestlcointprvtpub, Litecointtpvttub,
-		Nexaxprvxpub2},
+		Nexaxprvxpub2, Decredtprvtpub},
 	"m/49'/1'": {Bitcoinuprvupub, Groestlcoinuprvupub},
 	"m/84'/1'": {Bitcoinvprvvpub, BitcoinUprvUpub, BitcoinVprvVpub,
 		Groestlcoinvprvvpub, GroestlcoinUprvUpub, GroestlcoinVprvVpub},
@@ -420,6 +571,7 @@ var PathToHDBytesSlice = map[string][]VersionBytes{
 	"m/44'/29223'": {Nexaxprvxpub},
 	"m/44'/28'":    {Vertcoinvtcpvtcv},
 	"m/44'/1997'":  {Polispprvppub},
+	"m/44'/42'":    {Decreddprvdpub},
 	"m/84'/57'":    {Syscoinzprvzpub, SyscoinZprvZpub},
 }
 
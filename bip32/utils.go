@@ -6,7 +6,9 @@ import (
 	"encoding/binary"
 	"fmt"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/decred/dcrd/crypto/blake256"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/mearaj/bips/groestl"
 	"golang.org/x/crypto/ripemd160"
 	"io"
 	"math/big"
@@ -38,6 +40,28 @@ func HashRipeMD160onSha256(data []byte) ([]byte, error) {
 	return hash2, nil
 }
 
+// HashRipeMD160onBlake256 hashRipeMD160(blake256.Sum256(data)), the Hash160 of Decred
+func HashRipeMD160onBlake256(data []byte) ([]byte, error) {
+	hash := blake256.Sum256(data)
+	return hashRipeMD160(hash[:])
+}
+
+// ChecksumDblBlake256 is the first 4 bytes of blake256(blake256(data)),
+// the base58check checksum of Decred
+func ChecksumDblBlake256(data []byte) ([]byte, error) {
+	hash := blake256.Sum256(data)
+	hash = blake256.Sum256(hash[:])
+	return hash[:4], nil
+}
+
+// ChecksumDblGroestl512 is the first 4 bytes of groestl512(groestl512(data)),
+// the base58check checksum of Groestlcoin
+func ChecksumDblGroestl512(data []byte) ([]byte, error) {
+	hash := groestl.Sum512(data)
+	hash = groestl.Sum512(hash[:])
+	return hash[:4], nil
+}
+
 func ChecksumDblSha256(data []byte) ([]byte, error) {
 	hash := chainhash.DoubleHashB(data)
 	return hash[:4], nil
@@ -0,0 +1,269 @@
+package bip39
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Alternative encodings of a mnemonic sentence, as found on steel backup
+// plates and in wallets that don't store the full words.
+//
+// Every decoder below rebuilds the canonical sentence from the current word
+// list and verifies its checksum before returning it.
+
+// IndexBase tells whether word indices are counted from zero (0-2047)
+// or from one (1-2048).
+type IndexBase int
+
+const (
+	// ZeroBased indices run from 0 to 2047, like the word list itself.
+	ZeroBased IndexBase = iota
+	// OneBased indices run from 1 to 2048, as stamped on most backup plates.
+	OneBased
+	// AutoBase lets the decoder work out the base. It fails with
+	// ErrAmbiguousIndexBase if both bases give a valid mnemonic.
+	AutoBase
+)
+
+// AbbreviationLength is the number of leading letters that uniquely
+// identify a word of the english word list.
+const AbbreviationLength = 4
+
+var (
+	// ErrWordIndexOutOfRange is returned when a word index doesn't fit
+	// into the word list for the given IndexBase.
+	ErrWordIndexOutOfRange = errors.New("Word index out of range")
+
+	// ErrInvalidIndexBase is returned when the IndexBase isn't one of
+	// ZeroBased, OneBased or, when decoding, AutoBase.
+	ErrInvalidIndexBase = errors.New("Invalid word index base")
+
+	// ErrAmbiguousIndexBase is returned when indices decode to a valid
+	// mnemonic both as zero and as one based indices.
+	ErrAmbiguousIndexBase = errors.New("Word indices are valid both as zero and one based")
+
+	// ErrAmbiguousAbbreviation is returned when an abbreviation is the
+	// prefix of more than one word.
+	ErrAmbiguousAbbreviation = errors.New("Abbreviation matches more than one word")
+
+	// ErrUnknownAbbreviation is returned when an abbreviation isn't the
+	// prefix of any word.
+	ErrUnknownAbbreviation = errors.New("Abbreviation doesn't match any word")
+
+	// ErrMalformedBinary is returned when a binary string isn't made of
+	// 11 bit groups of 0 and 1.
+	ErrMalformedBinary = errors.New("Binary mnemonic must be 11 bit groups of 0 and 1")
+)
+
+// MnemonicToWordIndices returns the index of every word of the mnemonic.
+func MnemonicToWordIndices(mnemonic string, base IndexBase) ([]int, error) {
+	if base != ZeroBased && base != OneBased {
+		return nil, ErrInvalidIndexBase
+	}
+	if _, err := EntropyFromMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	words := strings.Fields(mnemonic)
+	indices := make([]int, len(words))
+	for i, w := range words {
+		indices[i] = wordMap[w] + int(base)
+	}
+	return indices, nil
+}
+
+// MnemonicFromWordIndices returns the mnemonic for the given word indices.
+// With AutoBase, an index of 0 means zero based and an index of 2048
+// means one based. Otherwise both bases are tried against the checksum.
+func MnemonicFromWordIndices(indices []int, base IndexBase) (string, error) {
+	if base != AutoBase {
+		return mnemonicFromIndices(indices, base)
+	}
+	hasZero, hasMax := false, false
+	for _, idx := range indices {
+		hasZero = hasZero || idx == 0
+		hasMax = hasMax || idx == len(wordList)
+	}
+	switch {
+	case hasZero && hasMax:
+		return "", ErrWordIndexOutOfRange
+	case hasZero:
+		return mnemonicFromIndices(indices, ZeroBased)
+	case hasMax:
+		return mnemonicFromIndices(indices, OneBased)
+	}
+	zero, zeroErr := mnemonicFromIndices(indices, ZeroBased)
+	one, oneErr := mnemonicFromIndices(indices, OneBased)
+	switch {
+	case zeroErr == nil && oneErr == nil:
+		return "", ErrAmbiguousIndexBase
+	case zeroErr == nil:
+		return zero, nil
+	case oneErr == nil:
+		return one, nil
+	}
+	return "", zeroErr
+}
+
+// MnemonicToIndicesString returns the word indices separated by spaces.
+func MnemonicToIndicesString(mnemonic string, base IndexBase) (string, error) {
+	indices, err := MnemonicToWordIndices(mnemonic, base)
+	if err != nil {
+		return "", err
+	}
+	fields := make([]string, len(indices))
+	for i, idx := range indices {
+		fields[i] = strconv.Itoa(idx)
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// MnemonicFromIndicesString parses word indices separated by spaces,
+// commas or dashes and returns the mnemonic.
+func MnemonicFromIndicesString(s string, base IndexBase) (string, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '-' || r == '\t' || r == '\n'
+	})
+	indices := make([]int, len(fields))
+	for i, f := range fields {
+		idx, err := strconv.Atoi(f)
+		if err != nil {
+			return "", fmt.Errorf("%w: word %d `%v`", ErrWordIndexOutOfRange, i+1, f)
+		}
+		indices[i] = idx
+	}
+	return MnemonicFromWordIndices(indices, base)
+}
+
+// MnemonicToAbbreviations returns the first AbbreviationLength letters
+// of every word, separated by spaces.
+func MnemonicToAbbreviations(mnemonic string) (string, error) {
+	if _, err := EntropyFromMnemonic(mnemonic); err != nil {
+		return "", err
+	}
+	words := strings.Fields(mnemonic)
+	for i, w := range words {
+		words[i] = abbreviate(w)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicFromAbbreviations expands every abbreviation to the only word
+// of the word list starting with it and returns the mnemonic.
+// Full words are accepted as well.
+func MnemonicFromAbbreviations(abbreviations string) (string, error) {
+	fields := strings.Fields(strings.ToLower(abbreviations))
+	words := make([]string, len(fields))
+	for i, f := range fields {
+		word, err := expandAbbreviation(f)
+		if err != nil {
+			return "", fmt.Errorf("%w: word %d `%v`", err, i+1, f)
+		}
+		words[i] = word
+	}
+	mnemonic := strings.Join(words, " ")
+	if _, err := EntropyFromMnemonic(mnemonic); err != nil {
+		return "", err
+	}
+	return mnemonic, nil
+}
+
+// MnemonicToBinary returns every word index as an 11 bit binary group,
+// separated by spaces.
+func MnemonicToBinary(mnemonic string) (string, error) {
+	indices, err := MnemonicToWordIndices(mnemonic, ZeroBased)
+	if err != nil {
+		return "", err
+	}
+	groups := make([]string, len(indices))
+	for i, idx := range indices {
+		groups[i] = fmt.Sprintf("%011b", idx)
+	}
+	return strings.Join(groups, " "), nil
+}
+
+// MnemonicFromBinary parses 11 bit binary groups and returns the mnemonic.
+// Groups may be separated by white space or written as one string.
+func MnemonicFromBinary(binary string) (string, error) {
+	bits := strings.Join(strings.Fields(binary), "")
+	if len(bits) == 0 || len(bits)%11 != 0 {
+		return "", ErrMalformedBinary
+	}
+	indices := make([]int, 0, len(bits)/11)
+	for i := 0; i < len(bits); i += 11 {
+		idx, err := strconv.ParseUint(bits[i:i+11], 2, 16)
+		if err != nil {
+			return "", fmt.Errorf("%w: word %d `%v`", ErrMalformedBinary, i/11+1, bits[i:i+11])
+		}
+		indices = append(indices, int(idx))
+	}
+	return mnemonicFromIndices(indices, ZeroBased)
+}
+
+// MnemonicToEntropyHex returns the entropy of the mnemonic in hex.
+func MnemonicToEntropyHex(mnemonic string) (string, error) {
+	entropy, err := EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(entropy), nil
+}
+
+// MnemonicFromEntropyHex returns the mnemonic for entropy given in hex,
+// with or without the 0x prefix.
+func MnemonicFromEntropyHex(entropyHex string) (string, error) {
+	entropyHex = strings.TrimPrefix(strings.TrimSpace(entropyHex), "0x")
+	entropy, err := hex.DecodeString(entropyHex)
+	if err != nil {
+		return "", err
+	}
+	return NewMnemonic(entropy)
+}
+
+func mnemonicFromIndices(indices []int, base IndexBase) (string, error) {
+	if base != ZeroBased && base != OneBased {
+		return "", ErrInvalidIndexBase
+	}
+	words := make([]string, len(indices))
+	for i, idx := range indices {
+		idx -= int(base)
+		if idx < 0 || idx >= len(wordList) {
+			return "", fmt.Errorf("%w: word %d `%d`", ErrWordIndexOutOfRange, i+1, idx+int(base))
+		}
+		words[i] = wordList[idx]
+	}
+	mnemonic := strings.Join(words, " ")
+	if _, err := EntropyFromMnemonic(mnemonic); err != nil {
+		return "", err
+	}
+	return mnemonic, nil
+}
+
+func abbreviate(word string) string {
+	if utf8.RuneCountInString(word) <= AbbreviationLength {
+		return word
+	}
+	return string([]rune(word)[:AbbreviationLength])
+}
+
+func expandAbbreviation(abbr string) (string, error) {
+	if _, ok := wordMap[abbr]; ok {
+		return abbr, nil
+	}
+	var found string
+	for _, w := range wordList {
+		if strings.HasPrefix(w, abbr) {
+			if found != "" {
+				return "", ErrAmbiguousAbbreviation
+			}
+			found = w
+		}
+	}
+	if found == "" {
+		return "", ErrUnknownAbbreviation
+	}
+	return found, nil
+}
@@ -0,0 +1,139 @@
+package bip39
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tyler-smith/assert"
+)
+
+func TestWordIndicesRoundTrip(t *testing.T) {
+	for _, vector := range testVectors() {
+		for _, base := range []IndexBase{ZeroBased, OneBased} {
+			s, err := MnemonicToIndicesString(vector.mnemonic, base)
+			assert.Nil(t, err)
+			mnemonic, err := MnemonicFromIndicesString(s, base)
+			assert.Nil(t, err)
+			assert.EqualString(t, vector.mnemonic, mnemonic)
+		}
+	}
+
+	indices, err := MnemonicToWordIndices("legal winner thank year wave sausage worth useful legal winner thank yellow", OneBased)
+	assert.Nil(t, err)
+	assertEqual(t, 1020, indices[0])
+	assertEqual(t, 2041, indices[11])
+}
+
+func TestWordIndicesAutoBase(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	decoded, err := MnemonicFromIndicesString("0 0 0 0 0 0 0 0 0 0 0 3", AutoBase)
+	assert.Nil(t, err)
+	assert.EqualString(t, mnemonic, decoded)
+
+	decoded, err = MnemonicFromIndicesString("2048 2048 2048 2048 2048 2048 2048 2048 2048 2048 2048 2038", AutoBase)
+	assert.Nil(t, err)
+	assert.EqualString(t, "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong", decoded)
+
+	_, err = MnemonicFromWordIndices([]int{0, 2048, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, AutoBase)
+	assert.True(t, errors.Is(err, ErrWordIndexOutOfRange))
+
+	// Search for indices which are valid both ways.
+	ambiguous := false
+	for last := 1; last < 2048 && !ambiguous; last++ {
+		indices := []int{5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, last}
+		_, zeroErr := mnemonicFromIndices(indices, ZeroBased)
+		_, oneErr := mnemonicFromIndices(indices, OneBased)
+		if zeroErr == nil && oneErr == nil {
+			ambiguous = true
+			_, err = MnemonicFromWordIndices(indices, AutoBase)
+			assertEqual(t, ErrAmbiguousIndexBase, err)
+		}
+	}
+	assert.True(t, ambiguous)
+}
+
+func TestWordIndicesOutOfRange(t *testing.T) {
+	_, err := MnemonicFromWordIndices([]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2048}, ZeroBased)
+	assert.True(t, errors.Is(err, ErrWordIndexOutOfRange))
+
+	_, err = MnemonicFromWordIndices([]int{0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, OneBased)
+	assert.True(t, errors.Is(err, ErrWordIndexOutOfRange))
+
+	_, err = MnemonicFromIndicesString("1 2 x", ZeroBased)
+	assert.True(t, errors.Is(err, ErrWordIndexOutOfRange))
+}
+
+func TestInvalidIndexBase(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	_, err := MnemonicToWordIndices(mnemonic, AutoBase)
+	assertEqual(t, ErrInvalidIndexBase, err)
+
+	_, err = MnemonicToIndicesString(mnemonic, IndexBase(7))
+	assertEqual(t, ErrInvalidIndexBase, err)
+
+	_, err = MnemonicFromWordIndices([]int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3}, IndexBase(-1))
+	assertEqual(t, ErrInvalidIndexBase, err)
+}
+
+func TestAbbreviationsRoundTrip(t *testing.T) {
+	for _, vector := range testVectors() {
+		abbr, err := MnemonicToAbbreviations(vector.mnemonic)
+		assert.Nil(t, err)
+		mnemonic, err := MnemonicFromAbbreviations(abbr)
+		assert.Nil(t, err)
+		assert.EqualString(t, vector.mnemonic, mnemonic)
+	}
+
+	abbr, err := MnemonicToAbbreviations("letter advice cage absurd amount doctor acoustic avoid letter advice cage above")
+	assert.Nil(t, err)
+	assert.EqualString(t, "lett advi cage absu amou doct acou avoi lett advi cage abov", abbr)
+
+	mnemonic, err := MnemonicFromAbbreviations("LETT ADVI CAGE ABSU AMOU DOCT ACOU AVOI LETT ADVI CAGE ABOV")
+	assert.Nil(t, err)
+	assert.EqualString(t, "letter advice cage absurd amount doctor acoustic avoid letter advice cage above", mnemonic)
+}
+
+func TestAbbreviationsErrors(t *testing.T) {
+	_, err := MnemonicFromAbbreviations("aba aba aba aba aba aba aba aba aba aba aba abo")
+	assert.True(t, errors.Is(err, ErrAmbiguousAbbreviation))
+
+	_, err = MnemonicFromAbbreviations("aban aban aban aban aban aban aban aban aban aban aban qqqq")
+	assert.True(t, errors.Is(err, ErrUnknownAbbreviation))
+
+	_, err = MnemonicFromAbbreviations("aban aban aban aban aban aban aban aban aban aban aban aban")
+	assertEqual(t, ErrChecksumIncorrect, err)
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for _, vector := range testVectors() {
+		binary, err := MnemonicToBinary(vector.mnemonic)
+		assert.Nil(t, err)
+		mnemonic, err := MnemonicFromBinary(binary)
+		assert.Nil(t, err)
+		assert.EqualString(t, vector.mnemonic, mnemonic)
+	}
+
+	binary, err := MnemonicToBinary("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+	assert.Nil(t, err)
+	assert.EqualString(t, "00000000000 00000000000 00000000000 00000000000 00000000000 00000000000 "+
+		"00000000000 00000000000 00000000000 00000000000 00000000000 00000000011", binary)
+
+	_, err = MnemonicFromBinary("0000000000")
+	assertEqual(t, ErrMalformedBinary, err)
+	_, err = MnemonicFromBinary("0000000000200000000000")
+	assert.True(t, errors.Is(err, ErrMalformedBinary))
+}
+
+func TestEntropyHexRoundTrip(t *testing.T) {
+	for _, vector := range testVectors() {
+		entropyHex, err := MnemonicToEntropyHex(vector.mnemonic)
+		assert.Nil(t, err)
+		assert.EqualString(t, vector.entropy, entropyHex)
+		mnemonic, err := MnemonicFromEntropyHex("0x" + entropyHex)
+		assert.Nil(t, err)
+		assert.EqualString(t, vector.mnemonic, mnemonic)
+	}
+
+	_, err := MnemonicFromEntropyHex("00")
+	assertEqual(t, ErrEntropyLengthInvalid, err)
+}
@@ -0,0 +1,157 @@
+package bip39
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mearaj/bips/bip39/wordlists"
+)
+
+// SeedQR payloads as defined by SeedSigner
+// https://github.com/SeedSigner/seedsigner/blob/dev/docs/seed_qr/README.md
+//
+// A standard SeedQR is the zero based index of every word as a 4 digit
+// decimal number, meant to be encoded in QR numeric mode.
+// A CompactSeedQR is the raw entropy, meant to be encoded in QR byte mode.
+// Both rely on word indices, hence on the english word list.
+
+// SeedQRDigitsPerWord is the number of decimal digits per word in a SeedQR
+const SeedQRDigitsPerWord = 4
+
+var (
+	// ErrInvalidSeedQR is returned when a SeedQR payload is malformed
+	ErrInvalidSeedQR = errors.New("Invalid SeedQR")
+
+	// ErrSeedQRWordCount is returned for mnemonics and payloads other than
+	// 12 and 24 words, or 16 and 32 bytes, the only sizes SeedQR defines
+	ErrSeedQRWordCount = errors.New("SeedQR is defined for 12 and 24 word mnemonics only")
+)
+
+// englishWordMap is the reverse lookup of the english word list, SeedQR
+// doesn't depend on the word list set with SetWordList
+var englishWordMap = map[string]int{}
+
+func init() {
+	for i, w := range wordlists.English {
+		englishWordMap[w] = i
+	}
+}
+
+// MnemonicToSeedQR returns the standard SeedQR digits of the english
+// mnemonic.
+func MnemonicToSeedQR(mnemonic string) (string, error) {
+	indices, err := englishWordIndices(mnemonic)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, idx := range indices {
+		sb.WriteString(fmt.Sprintf("%04d", idx))
+	}
+	return sb.String(), nil
+}
+
+// MnemonicFromSeedQR returns the english mnemonic of standard SeedQR digits.
+func MnemonicFromSeedQR(digits string) (string, error) {
+	digits = strings.TrimSpace(digits)
+	if len(digits) == 0 || len(digits)%SeedQRDigitsPerWord != 0 {
+		return "", ErrInvalidSeedQR
+	}
+	indices := make([]int, 0, len(digits)/SeedQRDigitsPerWord)
+	for i := 0; i < len(digits); i += SeedQRDigitsPerWord {
+		idx := 0
+		for _, c := range digits[i : i+SeedQRDigitsPerWord] {
+			if c < '0' || c > '9' {
+				return "", ErrInvalidSeedQR
+			}
+			idx = idx*10 + int(c-'0')
+		}
+		indices = append(indices, idx)
+	}
+	return englishMnemonicFromIndices(indices)
+}
+
+// MnemonicToCompactSeedQR returns the CompactSeedQR bytes of the english
+// mnemonic, which is its entropy.
+func MnemonicToCompactSeedQR(mnemonic string) ([]byte, error) {
+	indices, err := englishWordIndices(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return entropyFromIndices(indices), nil
+}
+
+// MnemonicFromCompactSeedQR returns the english mnemonic of CompactSeedQR
+// bytes.
+func MnemonicFromCompactSeedQR(data []byte) (string, error) {
+	if len(data) != 16 && len(data) != 32 {
+		return "", fmt.Errorf("%w: %d bytes", ErrSeedQRWordCount, len(data))
+	}
+	return NewMnemonicWithWordList(data, wordlists.English)
+}
+
+// englishWordIndices returns the english word list indices of a valid 12
+// or 24 word mnemonic
+func englishWordIndices(mnemonic string) ([]int, error) {
+	words := strings.Fields(mnemonic)
+	if err := validateSeedQRWordCount(len(words)); err != nil {
+		return nil, err
+	}
+	indices := make([]int, len(words))
+	for i, w := range words {
+		idx, ok := englishWordMap[w]
+		if !ok {
+			return nil, fmt.Errorf("%w: word %d `%v` isn't english", ErrInvalidMnemonic, i+1, w)
+		}
+		indices[i] = idx
+	}
+	if _, err := englishMnemonicFromIndices(indices); err != nil {
+		return nil, err
+	}
+	return indices, nil
+}
+
+// englishMnemonicFromIndices returns the english mnemonic of 12 or 24 zero
+// based indices after checking its checksum
+func englishMnemonicFromIndices(indices []int) (string, error) {
+	if err := validateSeedQRWordCount(len(indices)); err != nil {
+		return "", err
+	}
+	words := make([]string, len(indices))
+	for i, idx := range indices {
+		if idx < 0 || idx >= len(wordlists.English) {
+			return "", fmt.Errorf("%w: word %d `%d`", ErrWordIndexOutOfRange, i+1, idx)
+		}
+		words[i] = wordlists.English[idx]
+	}
+	mnemonic := strings.Join(words, " ")
+	expected, err := NewMnemonicWithWordList(entropyFromIndices(indices), wordlists.English)
+	if err != nil {
+		return "", err
+	}
+	if expected != mnemonic {
+		return "", ErrChecksumIncorrect
+	}
+	return mnemonic, nil
+}
+
+// entropyFromIndices returns the entropy bits of 11 bits word indices,
+// dropping the checksum bits
+func entropyFromIndices(indices []int) []byte {
+	entropy := make([]byte, len(indices)*11*32/33/8)
+	for bit := 0; bit < len(entropy)*8; bit++ {
+		idx := indices[bit/11]
+		if idx>>(10-bit%11)&1 == 1 {
+			entropy[bit/8] |= 0x80 >> (bit % 8)
+		}
+	}
+	return entropy
+}
+
+func validateSeedQRWordCount(count int) error {
+	if count != 12 && count != 24 {
+		return fmt.Errorf("%w: %d words", ErrSeedQRWordCount, count)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package bip39
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mearaj/bips/bip39/wordlists"
+
+	"github.com/tyler-smith/assert"
+)
+
+func TestSeedQR(t *testing.T) {
+	for _, v := range []struct {
+		mnemonic string
+		seedQR   string
+	}{
+		{
+			mnemonic: "attack pizza motion avocado network gather crop fresh patrol unusual wild holiday candy pony ranch winter theme error hybrid van cereal salon goddess expire",
+			seedQR:   "011513251154012711900771041507421289190620080870026613431420201617920614089619290300152408010643",
+		},
+		{
+			mnemonic: "forum undo fragile fade shy sign arrest garment culture tube off merit",
+			seedQR:   "073318950739065415961602009907670428187212261116",
+		},
+	} {
+		seedQR, err := MnemonicToSeedQR(v.mnemonic)
+		assert.Nil(t, err)
+		assert.EqualString(t, v.seedQR, seedQR)
+
+		mnemonic, err := MnemonicFromSeedQR(v.seedQR)
+		assert.Nil(t, err)
+		assert.EqualString(t, v.mnemonic, mnemonic)
+
+		compact, err := MnemonicToCompactSeedQR(v.mnemonic)
+		assert.Nil(t, err)
+		mnemonic, err = MnemonicFromCompactSeedQR(compact)
+		assert.Nil(t, err)
+		assert.EqualString(t, v.mnemonic, mnemonic)
+	}
+}
+
+func TestSeedQRInvalid(t *testing.T) {
+	_, err := MnemonicFromSeedQR("07331895073")
+	assertEqual(t, ErrInvalidSeedQR, err)
+
+	_, err = MnemonicFromSeedQR("0733189507390654159616020099076704281872122611x6")
+	assertEqual(t, ErrInvalidSeedQR, err)
+
+	_, err = MnemonicFromSeedQR("073318950739065415961602009907670428187212261117")
+	assertEqual(t, ErrChecksumIncorrect, err)
+
+	for _, size := range []int{15, 20, 24, 28} {
+		_, err = MnemonicFromCompactSeedQR(make([]byte, size))
+		assert.True(t, errors.Is(err, ErrSeedQRWordCount))
+	}
+}
+
+// SeedQR only defines 12 and 24 words, 16 and 32 bytes
+func TestSeedQRWordCount(t *testing.T) {
+	for _, entropyLength := range []int{20, 24, 28} {
+		mnemonic, err := NewMnemonic(make([]byte, entropyLength))
+		assert.Nil(t, err)
+		_, err = MnemonicToSeedQR(mnemonic)
+		assert.True(t, errors.Is(err, ErrSeedQRWordCount))
+		_, err = MnemonicToCompactSeedQR(mnemonic)
+		assert.True(t, errors.Is(err, ErrSeedQRWordCount))
+	}
+	// 15 words of SeedQR digits
+	_, err := MnemonicFromSeedQR(strings.Repeat("0000", 14) + "0003")
+	assert.True(t, errors.Is(err, ErrSeedQRWordCount))
+}
+
+// SeedQR uses the english word list whatever the package-wide one is
+func TestSeedQREnglishOnly(t *testing.T) {
+	mnemonic := "forum undo fragile fade shy sign arrest garment culture tube off merit"
+	digits := "073318950739065415961602009907670428187212261116"
+	SetWordList(wordlists.Spanish)
+	defer SetWordList(wordlists.English)
+
+	seedQR, err := MnemonicToSeedQR(mnemonic)
+	assert.Nil(t, err)
+	assert.EqualString(t, digits, seedQR)
+	decoded, err := MnemonicFromSeedQR(digits)
+	assert.Nil(t, err)
+	assert.EqualString(t, mnemonic, decoded)
+	compact, err := MnemonicToCompactSeedQR(mnemonic)
+	assert.Nil(t, err)
+	decoded, err = MnemonicFromCompactSeedQR(compact)
+	assert.Nil(t, err)
+	assert.EqualString(t, mnemonic, decoded)
+}
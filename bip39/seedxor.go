@@ -0,0 +1,94 @@
+package bip39
+
+import (
+	"crypto/rand"
+	"errors"
+)
+
+// Seed XOR as implemented by Coldcard
+// https://seedxor.com/
+//
+// The entropy of a mnemonic is split into parts whose entropies xor back
+// to the original one. Every part is a valid mnemonic on its own and can
+// be loaded as a decoy wallet.
+
+var (
+	// ErrSeedXORPartsCount is returned when splitting into less than two parts.
+	ErrSeedXORPartsCount = errors.New("Seed XOR needs at least two parts")
+
+	// ErrSeedXORLength is returned for mnemonics other than 12, 18 or 24 words,
+	// or when the parts to combine aren't of the same length.
+	ErrSeedXORLength = errors.New("Seed XOR parts must all be 12, 18 or 24 words")
+)
+
+// SplitSeedXOR splits the mnemonic into parts mnemonics of the same length.
+func SplitSeedXOR(mnemonic string, parts int) ([]string, error) {
+	if parts < 2 {
+		return nil, ErrSeedXORPartsCount
+	}
+	entropy, err := seedXOREntropy(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	last := append([]byte(nil), entropy...)
+	mnemonics := make([]string, 0, parts)
+	for i := 0; i < parts-1; i++ {
+		part := make([]byte, len(entropy))
+		if _, err = rand.Read(part); err != nil {
+			return nil, err
+		}
+		xorBytes(last, part)
+		m, err := NewMnemonic(part)
+		if err != nil {
+			return nil, err
+		}
+		mnemonics = append(mnemonics, m)
+	}
+	m, err := NewMnemonic(last)
+	if err != nil {
+		return nil, err
+	}
+	return append(mnemonics, m), nil
+}
+
+// CombineSeedXOR returns the mnemonic whose entropy is the xor of the
+// entropies of the given mnemonics.
+func CombineSeedXOR(mnemonics []string) (string, error) {
+	if len(mnemonics) < 2 {
+		return "", ErrSeedXORPartsCount
+	}
+	var result []byte
+	for _, m := range mnemonics {
+		entropy, err := seedXOREntropy(m)
+		if err != nil {
+			return "", err
+		}
+		if result == nil {
+			result = entropy
+			continue
+		}
+		if len(entropy) != len(result) {
+			return "", ErrSeedXORLength
+		}
+		xorBytes(result, entropy)
+	}
+	return NewMnemonic(result)
+}
+
+func seedXOREntropy(mnemonic string) ([]byte, error) {
+	entropy, err := EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	switch len(entropy) {
+	case 16, 24, 32:
+		return entropy, nil
+	}
+	return nil, ErrSeedXORLength
+}
+
+func xorBytes(dst, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
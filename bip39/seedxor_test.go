@@ -0,0 +1,55 @@
+package bip39
+
+import (
+	"testing"
+
+	"github.com/tyler-smith/assert"
+)
+
+// Ref https://github.com/Coldcard/firmware/blob/master/docs/seed-xor.md
+func TestCombineSeedXOR(t *testing.T) {
+	mnemonic, err := CombineSeedXOR([]string{
+		"romance wink lottery autumn shop bring dawn tongue range crater truth ability miss spice fitness easy legal release recall obey exchange recycle dragon room",
+		"lion misery divide hurry latin fluid camp advance illegal lab pyramid unaware eager fringe sick camera series noodle toy crowd jeans select depth lounge",
+		"vault nominee cradle silk own frown throw leg cactus recall talent worry gadget surface shy planet purpose coffee drip few seven term squeeze educate",
+	})
+	assert.Nil(t, err)
+	assert.EqualString(t, "silent toe meat possible chair blossom wait occur this worth option bag nurse find fish scene bench asthma bike wage world quit primary indoor", mnemonic)
+}
+
+func TestSplitSeedXOR(t *testing.T) {
+	for _, vector := range testVectors() {
+		words := len(splitWords(vector.mnemonic))
+		parts, err := SplitSeedXOR(vector.mnemonic, 3)
+		if words != 12 && words != 18 && words != 24 {
+			assertEqual(t, ErrSeedXORLength, err)
+			continue
+		}
+		assert.Nil(t, err)
+		assertEqual(t, 3, len(parts))
+		for _, part := range parts {
+			assert.True(t, IsMnemonicValid(part))
+			assertEqual(t, words, len(splitWords(part)))
+		}
+		mnemonic, err := CombineSeedXOR(parts)
+		assert.Nil(t, err)
+		assert.EqualString(t, vector.mnemonic, mnemonic)
+	}
+}
+
+func TestSeedXORErrors(t *testing.T) {
+	m12 := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	m24 := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"
+
+	_, err := SplitSeedXOR(m12, 1)
+	assertEqual(t, ErrSeedXORPartsCount, err)
+	_, err = CombineSeedXOR([]string{m12})
+	assertEqual(t, ErrSeedXORPartsCount, err)
+	_, err = CombineSeedXOR([]string{m12, m24})
+	assertEqual(t, ErrSeedXORLength, err)
+}
+
+func splitWords(mnemonic string) []string {
+	words, _ := splitMnemonicWords(mnemonic)
+	return words
+}
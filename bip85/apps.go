@@ -0,0 +1,185 @@
+package bip85
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math/bits"
+	"strings"
+
+	"github.com/mearaj/bips/bip32"
+	"github.com/mearaj/bips/bip39"
+	"github.com/mearaj/bips/bip39/wordlists"
+	"github.com/mearaj/bips/netparams"
+)
+
+// Language is the bip39 language code of the BIP39 application
+type Language uint32
+
+const (
+	English Language = iota
+	Japanese
+	Korean
+	Spanish
+	ChineseSimplified
+	ChineseTraditional
+	French
+	Italian
+	Czech
+	Portuguese
+)
+
+var languageWordLists = map[Language][]string{
+	English:            wordlists.English,
+	Japanese:           wordlists.Japanese,
+	Korean:             wordlists.Korean,
+	Spanish:            wordlists.Spanish,
+	ChineseSimplified:  wordlists.ChineseSimplified,
+	ChineseTraditional: wordlists.ChineseTraditional,
+	French:             wordlists.French,
+	Italian:            wordlists.Italian,
+	Czech:              wordlists.Czech,
+	Portuguese:         wordlists.Portuguese,
+}
+
+// japaneseSeparator is the ideographic space separating the words of the
+// Japanese mnemonics
+const japaneseSeparator = "\u3000"
+
+// base85Alphabet is the RFC 1924 character set, as used by python's base64.b85encode
+const base85Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz!#$%&()*+-;<=>?@^_`{|}~"
+
+// BIP39 derives a mnemonic of words count in language, its words separated
+// by spaces, ideographic ones for Japanese,
+// path m/83696968'/39'/{language}'/{words}'/{index}'
+func BIP39(master *bip32.Key, language Language, words, index uint32) (string, error) {
+	list, ok := languageWordLists[language]
+	if !ok {
+		return "", ErrInvalidLanguage
+	}
+	if words%3 != 0 || words < 12 || words > 24 {
+		return "", ErrInvalidWordsCount
+	}
+	entropy, err := deriveEntropy(master, AppBIP39, uint32(language), words, index)
+	if err != nil {
+		return "", err
+	}
+	mnemonic, err := bip39.NewMnemonicWithWordList(entropy[:words*4/3], list)
+	if err != nil || language != Japanese {
+		return mnemonic, err
+	}
+	return strings.ReplaceAll(mnemonic, " ", japaneseSeparator), nil
+}
+
+// WIF derives a compressed bitcoin mainnet private key in wallet import format,
+// path m/83696968'/2'/{index}'
+func WIF(master *bip32.Key, index uint32) (string, error) {
+	entropy, err := deriveEntropy(master, AppHDSeedWIF, index)
+	if err != nil {
+		return "", err
+	}
+	return netparams.Bitcoin.EncodeWIF(entropy[:32], true)
+}
+
+// XPRV derives a master extended private key, path m/83696968'/32'/{index}'
+// The first half of the entropy is the chain code and the second half the private key
+func XPRV(master *bip32.Key, index uint32) (*bip32.Key, error) {
+	entropy, err := deriveEntropy(master, AppXPRV, index)
+	if err != nil {
+		return nil, err
+	}
+	pvtKey := bip32.PvtKeyBytes(entropy[32:])
+	if err = bip32.ValidatePrivateKey(pvtKey); err != nil {
+		return nil, err
+	}
+	key := &bip32.Key{}
+	key.SetVersion(bip32.DefaultMainnetVersion.PvtKeyFlagBytes())
+	copy(key[bip32.ChainCodeStartIndex:bip32.ChainCodeEndIndex], entropy[:32])
+	copy(key[bip32.PvtKeyStartIndex:bip32.PvtKeyEndIndex], pvtKey[:])
+	return key, nil
+}
+
+// Hex derives numBytes of entropy as hex, path m/83696968'/128169'/{numBytes}'/{index}'
+func Hex(master *bip32.Key, numBytes, index uint32) (string, error) {
+	if numBytes < 16 || numBytes > EntropyLength {
+		return "", ErrInvalidBytesCount
+	}
+	entropy, err := deriveEntropy(master, AppHex, numBytes, index)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(entropy[:numBytes]), nil
+}
+
+// PwdBase64 derives a password of length between 20 and 86,
+// path m/83696968'/707764'/{length}'/{index}'
+func PwdBase64(master *bip32.Key, length, index uint32) (string, error) {
+	if length < 20 || length > 86 {
+		return "", ErrInvalidPwdLength
+	}
+	entropy, err := deriveEntropy(master, AppPwdBase64, length, index)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(entropy)[:length], nil
+}
+
+// PwdBase85 derives a password of length between 10 and 80,
+// path m/83696968'/707785'/{length}'/{index}'
+func PwdBase85(master *bip32.Key, length, index uint32) (string, error) {
+	if length < 10 || length > 80 {
+		return "", ErrInvalidPwdLength
+	}
+	entropy, err := deriveEntropy(master, AppPwdBase85, length, index)
+	if err != nil {
+		return "", err
+	}
+	return base85Encode(entropy)[:length], nil
+}
+
+// Dice derives rolls of a dice with sides, each roll being between 0 and sides-1,
+// path m/83696968'/89101'/{sides}'/{rolls}'/{index}'
+func Dice(master *bip32.Key, sides, rolls, index uint32) ([]uint32, error) {
+	if sides < 2 {
+		return nil, ErrInvalidDiceSides
+	}
+	if rolls < 1 {
+		return nil, ErrInvalidDiceRolls
+	}
+	entropy, err := deriveEntropy(master, AppDice, sides, rolls, index)
+	if err != nil {
+		return nil, err
+	}
+	drng := NewDRNG(entropy)
+	bitsPerRoll := bits.Len32(sides - 1)
+	bytesPerRoll := (bitsPerRoll + 7) / 8
+	buf := make([]byte, bytesPerRoll)
+	result := make([]uint32, 0, rolls)
+	for uint32(len(result)) < rolls {
+		_, _ = drng.Read(buf)
+		var trial uint64
+		for _, b := range buf {
+			trial = trial<<8 | uint64(b)
+		}
+		// drop the excess low bits and reject rolls out of range
+		trial >>= uint(bytesPerRoll*8 - bitsPerRoll)
+		if trial < uint64(sides) {
+			result = append(result, uint32(trial))
+		}
+	}
+	return result, nil
+}
+
+// base85Encode encodes data, a multiple of 4 bytes, with base85Alphabet
+func base85Encode(data []byte) string {
+	out := make([]byte, 0, len(data)/4*5)
+	for i := 0; i+4 <= len(data); i += 4 {
+		v := uint32(data[i])<<24 | uint32(data[i+1])<<16 | uint32(data[i+2])<<8 | uint32(data[i+3])
+		var chunk [5]byte
+		for j := 4; j >= 0; j-- {
+			chunk[j] = base85Alphabet[v%85]
+			v /= 85
+		}
+		out = append(out, chunk[:]...)
+	}
+	return string(out)
+}
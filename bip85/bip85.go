@@ -0,0 +1,115 @@
+// Package bip85 is the Golang implementation of BIP85,
+// Deterministic Entropy From BIP32 Keychains.
+//
+// Child secrets for other wallets and applications are derived from
+// a single bip32 master key, so that they are all recoverable from
+// one backup.
+//
+// The official BIP85 spec can be found at
+// https://github.com/bitcoin/bips/blob/master/bip-0085.mediawiki
+package bip85
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"errors"
+
+	"github.com/mearaj/bips/bip32"
+	"golang.org/x/crypto/sha3"
+)
+
+// Purpose is the first, hardened, path component of every BIP85 derivation
+const Purpose = 83696968
+
+// Application numbers, the second path component
+const (
+	AppBIP39      = 39
+	AppHDSeedWIF  = 2
+	AppXPRV       = 32
+	AppHex        = 128169
+	AppPwdBase64  = 707764
+	AppPwdBase85  = 707785
+	AppDice       = 89101
+	EntropyLength = 64
+)
+
+var (
+	ErrNonPrivateKey     = errors.New("bip85 derivation requires a private master key")
+	ErrInvalidPath       = errors.New("bip85 path must be hardened and start with m/83696968'")
+	ErrInvalidIndex      = errors.New("index must be lesser than 2^31")
+	ErrInvalidLanguage   = errors.New("unknown bip39 language")
+	ErrInvalidWordsCount = errors.New("words count must be 12, 15, 18, 21 or 24")
+	ErrInvalidBytesCount = errors.New("hex bytes count must be between 16 and 64")
+	ErrInvalidPwdLength  = errors.New("password length is out of range")
+	ErrInvalidDiceSides  = errors.New("dice must have at least 2 sides")
+	ErrInvalidDiceRolls  = errors.New("dice rolls must be at least 1")
+)
+
+var hmacKey = []byte("bip-entropy-from-k")
+
+// DeriveEntropy derives the child key of master at path and returns the
+// 64 bytes HMAC-SHA512 of its private key. Every component of path must
+// be hardened and the first one must be Purpose.
+func DeriveEntropy(master *bip32.Key, path bip32.Path) ([]byte, error) {
+	values, err := path.ValuesAtDepth()
+	if err != nil {
+		return nil, err
+	}
+	// ValuesAtDepth starts with the value of m
+	values = values[1:]
+	if len(values) == 0 || values[0] != Purpose+bip32.FirstHardenedChild {
+		return nil, ErrInvalidPath
+	}
+	indices := make([]uint32, len(values)-1)
+	for i, v := range values[1:] {
+		if v < bip32.FirstHardenedChild {
+			return nil, ErrInvalidPath
+		}
+		indices[i] = v - bip32.FirstHardenedChild
+	}
+	return deriveEntropy(master, indices...)
+}
+
+// deriveEntropy derives the entropy at m/83696968'/indices[0]'/...
+func deriveEntropy(master *bip32.Key, indices ...uint32) ([]byte, error) {
+	if !master.IsPrivate() {
+		return nil, ErrNonPrivateKey
+	}
+	key, err := master.NewChildKey(Purpose + bip32.FirstHardenedChild)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range indices {
+		if i >= bip32.FirstHardenedChild {
+			return nil, ErrInvalidIndex
+		}
+		key, err = key.NewChildKey(i + bip32.FirstHardenedChild)
+		if err != nil {
+			return nil, err
+		}
+	}
+	hm := hmac.New(sha512.New, hmacKey)
+	_, err = hm.Write(key[bip32.PvtKeyStartIndex:bip32.PvtKeyEndIndex])
+	if err != nil {
+		return nil, err
+	}
+	return hm.Sum(nil), nil
+}
+
+// DRNG is BIP85-DRNG-SHAKE256, a deterministic random number generator
+// seeded with the 64 bytes of derived entropy
+type DRNG struct {
+	shake sha3.ShakeHash
+}
+
+// NewDRNG returns the DRNG seeded with entropy, usually the result of DeriveEntropy
+func NewDRNG(entropy []byte) *DRNG {
+	shake := sha3.NewShake256()
+	_, _ = shake.Write(entropy) // err is always nil
+	return &DRNG{shake: shake}
+}
+
+// Read fills p with the next bytes of the DRNG, it never fails
+func (d *DRNG) Read(p []byte) (int, error) {
+	return d.shake.Read(p)
+}
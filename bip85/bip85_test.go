@@ -0,0 +1,125 @@
+package bip85
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/mearaj/bips/bip32"
+	"github.com/mearaj/bips/bip39"
+	"github.com/mearaj/bips/bip39/wordlists"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0085.mediawiki
+const masterXprv = "xprv9s21ZrQH143K2LBWUUQRFXhucrQqBpKdRRxNVq2zBqsx8HVqFk2uYo8kmbaLLHRdqtQpUm98uKfu3vca1LqdGhUtyoFnCNkfmXRyPXLjbKb"
+
+func masterKey(t *testing.T) *bip32.Key {
+	key, err := bip32.B58Deserialize(masterXprv)
+	require.NoError(t, err)
+	return &key
+}
+
+func TestDeriveEntropy(t *testing.T) {
+	master := masterKey(t)
+	vectors := []struct {
+		path    bip32.Path
+		entropy string
+	}{
+		{"m/83696968'/0'/0'", "efecfbccffea313214232d29e71563d941229afb4338c21f9517c41aaa0d16f00b83d2a09ef747e7a64e8e2bd5a14869e693da66ce94ac2da570ab7ee48618f7"},
+		{"m/83696968'/0'/1'", "70c6e3e8ebee8dc4c0dbba66076819bb8c09672527c4277ca8729532ad711872218f826919f6b67218adde99018a6df9095ab2b58d803b5b93ec9802085a690e"},
+	}
+	for _, v := range vectors {
+		entropy, err := DeriveEntropy(master, v.path)
+		assert.NoError(t, err)
+		assert.Equal(t, v.entropy, hex.EncodeToString(entropy))
+	}
+
+	_, err := DeriveEntropy(master, "m/44'/0'")
+	assert.Equal(t, ErrInvalidPath, err)
+	_, err = DeriveEntropy(master, "m/83696968'/0'/0")
+	assert.Equal(t, ErrInvalidPath, err)
+	pub := master.PublicKeyExtended()
+	_, err = DeriveEntropy(&pub, "m/83696968'/0'/0'")
+	assert.Equal(t, ErrNonPrivateKey, err)
+}
+
+func TestDRNG(t *testing.T) {
+	entropy, err := DeriveEntropy(masterKey(t), "m/83696968'/0'/0'")
+	require.NoError(t, err)
+	out := make([]byte, 80)
+	_, err = NewDRNG(entropy).Read(out)
+	assert.NoError(t, err)
+	assert.Equal(t, "b78b1ee6b345eae6836c2d53d33c64cdaf9a696487be81b03e822dc84b3f1cd883d7559e53d175f243e4c349e822a957bbff9224bc5dde9492ef54e8a439f6bc8c7355b87a925a37ee405a7502991111", hex.EncodeToString(out))
+}
+
+func TestBIP39(t *testing.T) {
+	master := masterKey(t)
+	vectors := map[uint32]string{
+		12: "girl mad pet galaxy egg matter matrix prison refuse sense ordinary nose",
+		18: "near account window bike charge season chef number sketch tomorrow excuse sniff circle vital hockey outdoor supply token",
+		24: "puppy ocean match cereal symbol another shed magic wrap hammer bulb intact gadget divorce twin tonight reason outdoor destroy simple truth cigar social volcano",
+	}
+	for words, expected := range vectors {
+		mnemonic, err := BIP39(master, English, words, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, mnemonic)
+	}
+	mnemonic, err := BIP39(master, Czech, 15, 0)
+	assert.NoError(t, err)
+	assert.Len(t, strings.Fields(mnemonic), 15)
+
+	// the words of Japanese mnemonics are separated by ideographic spaces and
+	// are in the NFKD form of the word list
+	mnemonic, err = BIP39(master, Japanese, 12, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "おまいり　にんてい　こふん　ぎんいろ　にんい　ぜんご　ひめい　まほう　たたみ　さとう　ざいたく　あてな", mnemonic)
+	assert.NotContains(t, mnemonic, " ")
+	entropy, err := DeriveEntropy(master, "m/83696968'/39'/1'/12'/0'")
+	require.NoError(t, err)
+	spaced, err := bip39.NewMnemonicWithWordList(entropy[:16], wordlists.Japanese)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Fields(spaced), strings.Split(mnemonic, "\u3000"))
+
+	_, err = BIP39(master, English, 13, 0)
+	assert.Equal(t, ErrInvalidWordsCount, err)
+	_, err = BIP39(master, Language(10), 12, 0)
+	assert.Equal(t, ErrInvalidLanguage, err)
+}
+
+func TestApplications(t *testing.T) {
+	master := masterKey(t)
+
+	wif, err := WIF(master, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Kzyv4uF39d4Jrw2W7UryTHwZr1zQVNk4dAFyqE6BuMrMh1Za7uhp", wif)
+
+	xprv, err := XPRV(master, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "xprv9s21ZrQH143K2srSbCSg4m4kLvPMzcWydgmKEnMmoZUurYuBuYG46c6P71UGXMzmriLzCCBvKQWBUv3vPB3m1SATMhp3uEjXHJ42jFg7myX", xprv.String())
+
+	hexStr, err := Hex(master, 64, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "492db4698cf3b73a5a24998aa3e9d7fa96275d85724a91e71aa2d645442f878555d078fd1f1f67e368976f04137b1f7a0d19232136ca50c44614af72b5582a5c", hexStr)
+	_, err = Hex(master, 15, 0)
+	assert.Equal(t, ErrInvalidBytesCount, err)
+
+	pwd, err := PwdBase64(master, 21, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "dKLoepugzdVJvdL56ogNV", pwd)
+	_, err = PwdBase64(master, 87, 0)
+	assert.Equal(t, ErrInvalidPwdLength, err)
+
+	pwd, err = PwdBase85(master, 12, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "_s`{TW89)i4`", pwd)
+	_, err = PwdBase85(master, 9, 0)
+	assert.Equal(t, ErrInvalidPwdLength, err)
+
+	rolls, err := Dice(master, 6, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 0, 0, 2, 0, 1, 5, 5, 2, 4}, rolls)
+	_, err = Dice(master, 1, 10, 0)
+	assert.Equal(t, ErrInvalidDiceSides, err)
+}
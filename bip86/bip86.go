@@ -0,0 +1,121 @@
+// Package bip86 is the Golang implementation of BIP86, key derivation for
+// single key P2TR outputs, and of the BIP341 taproot key tweak it relies on.
+//
+// The output key of a taproot address is the x-only internal key tweaked
+// by the tagged hash of itself and of the script tree merkle root. BIP86
+// keys commit to an empty script tree, there is no merkle root at all.
+//
+// The official specs can be found at
+// https://github.com/bitcoin/bips/blob/master/bip-0086.mediawiki
+// https://github.com/bitcoin/bips/blob/master/bip-0341.mediawiki
+package bip86
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+const (
+	// Purpose is the BIP86 purpose path component, m/86'
+	Purpose = 86
+	// XOnlyPubKeyLength is the length of a BIP340 x-only public key
+	XOnlyPubKeyLength = 32
+	// TapTweakTag is the BIP340 tag of the key tweak hash
+	TapTweakTag = "TapTweak"
+)
+
+var (
+	ErrInvalidPubKey     = errors.New("invalid public key")
+	ErrInvalidPvtKey     = errors.New("invalid private key")
+	ErrInvalidMerkleRoot = errors.New("merkle root must be 32 bytes")
+	ErrInvalidTweak      = errors.New("tweak is not a valid scalar")
+)
+
+// TaggedHash is the BIP340 hash sha256(sha256(tag) || sha256(tag) || msgs...)
+func TaggedHash(tag string, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, msg := range msgs {
+		h.Write(msg)
+	}
+	return h.Sum(nil)
+}
+
+// XOnlyPubKey returns the 32 bytes x coordinate of a compressed or x-only public key
+func XOnlyPubKey(pubKey []byte) ([]byte, error) {
+	switch len(pubKey) {
+	case XOnlyPubKeyLength:
+		pubKey = append([]byte{secp256k1.PubKeyFormatCompressedEven}, pubKey...)
+	case secp256k1.PubKeyBytesLenCompressed:
+	default:
+		return nil, ErrInvalidPubKey
+	}
+	key, err := secp256k1.ParsePubKey(pubKey)
+	if err != nil {
+		return nil, ErrInvalidPubKey
+	}
+	return key.SerializeCompressed()[1:], nil
+}
+
+// TweakPubKey returns the x-only output key of the internal public key
+// pubKey, compressed or x-only, committing to the script tree merkle root.
+// A nil merkleRoot is the BIP86 key path only commitment.
+func TweakPubKey(pubKey, merkleRoot []byte) ([]byte, error) {
+	xOnly, err := XOnlyPubKey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	tweak, err := tapTweak(xOnly, merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	// lift_x picks the point of even y
+	internal, _ := secp256k1.ParsePubKey(append([]byte{secp256k1.PubKeyFormatCompressedEven}, xOnly...))
+	var p, t, q secp256k1.JacobianPoint
+	internal.AsJacobian(&p)
+	secp256k1.ScalarBaseMultNonConst(tweak, &t)
+	secp256k1.AddNonConst(&p, &t, &q)
+	if (q.X.IsZero() && q.Y.IsZero()) || q.Z.IsZero() {
+		return nil, ErrInvalidTweak
+	}
+	q.ToAffine()
+	return secp256k1.NewPublicKey(&q.X, &q.Y).SerializeCompressed()[1:], nil
+}
+
+// TweakPvtKey returns the private key of the output key of TweakPubKey,
+// the one signing key path spends
+func TweakPvtKey(pvtKey, merkleRoot []byte) ([]byte, error) {
+	var d secp256k1.ModNScalar
+	if len(pvtKey) != 32 || d.SetByteSlice(pvtKey) || d.IsZero() {
+		return nil, ErrInvalidPvtKey
+	}
+	pubKey := secp256k1.NewPrivateKey(&d).PubKey().SerializeCompressed()
+	if pubKey[0] == secp256k1.PubKeyFormatCompressedOdd {
+		d.Negate()
+	}
+	tweak, err := tapTweak(pubKey[1:], merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	d.Add(tweak)
+	if d.IsZero() {
+		return nil, ErrInvalidTweak
+	}
+	out := d.Bytes()
+	return out[:], nil
+}
+
+func tapTweak(xOnly, merkleRoot []byte) (*secp256k1.ModNScalar, error) {
+	if merkleRoot != nil && len(merkleRoot) != sha256.Size {
+		return nil, ErrInvalidMerkleRoot
+	}
+	var tweak secp256k1.ModNScalar
+	if tweak.SetByteSlice(TaggedHash(TapTweakTag, xOnly, merkleRoot)) {
+		return nil, ErrInvalidTweak
+	}
+	return &tweak, nil
+}
@@ -0,0 +1,47 @@
+package bip86
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0086.mediawiki#test-vectors
+func TestTweakPubKey(t *testing.T) {
+	vectors := []struct {
+		internalKey string
+		outputKey   string
+	}{
+		{"cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115", "a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c"},
+		{"83dfe85a3151d2517290da461fe2815591ef69f2b18a2ce63f01697a8b313145", "a82f29944d65b86ae6b5e5cc75e294ead6c59391a1edc5e016e3498c67fc7bbb"},
+		{"399f1b2f4393f29a18c937859c5dd8a77350103157eb880f02e8c08214277cef", "882d74e5d0572d5a816cef0041a96b6c1de832f6f9676d9605c44d5e9a97d3dc"},
+	}
+	for _, v := range vectors {
+		internalKey, _ := hex.DecodeString(v.internalKey)
+		outputKey, err := TweakPubKey(internalKey, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, v.outputKey, hex.EncodeToString(outputKey))
+	}
+}
+
+func TestTweakPvtKey(t *testing.T) {
+	merkleRoot := TaggedHash("TapLeaf", []byte("script"))
+	for _, root := range [][]byte{nil, merkleRoot} {
+		for i := byte(1); i < 5; i++ {
+			pvtKey := make([]byte, 32)
+			pvtKey[0], pvtKey[31] = i, i*7
+			pubKey := secp256k1.PrivKeyFromBytes(pvtKey).PubKey().SerializeCompressed()
+			outputKey, err := TweakPubKey(pubKey, root)
+			assert.NoError(t, err)
+			tweaked, err := TweakPvtKey(pvtKey, root)
+			assert.NoError(t, err)
+			assert.Equal(t, outputKey, secp256k1.PrivKeyFromBytes(tweaked).PubKey().SerializeCompressed()[1:])
+		}
+	}
+	_, err := TweakPubKey(make([]byte, 32), make([]byte, 31))
+	assert.Error(t, err)
+	_, err = TweakPvtKey(make([]byte, 32), nil)
+	assert.Equal(t, ErrInvalidPvtKey, err)
+}
@@ -0,0 +1,159 @@
+// Package cashaddr is the Golang implementation of the CashAddr address
+// format of Bitcoin Cash, a prefix and the base32 of a version byte and a
+// hash with a 40 bits BCH code checksum, also used by Nexa for its pay to
+// public key template addresses.
+//
+// The official spec can be found at
+// https://github.com/bitcoincashorg/bitcoincash.org/blob/master/spec/cashaddr.md
+package cashaddr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mearaj/bips/bech32"
+)
+
+// Prefixes of the networks
+const (
+	PrefixBitcoinCash        = "bitcoincash"
+	PrefixBitcoinCashTestnet = "bchtest"
+	PrefixBitcoinCashRegtest = "bchreg"
+	PrefixNexa               = "nexa"
+	PrefixNexaTestnet        = "nexatest"
+)
+
+// AddrType is the type in the version byte of an address
+type AddrType byte
+
+const (
+	P2PKH AddrType = 0
+	P2SH  AddrType = 1
+	// P2PKT is the Nexa pay to public key template type, whose payload is
+	// the serialized output script and not a hash
+	P2PKT AddrType = 19
+)
+
+const checksumLength = 8
+
+var (
+	ErrMixedCase        = errors.New("cashaddr must not mix upper and lower case")
+	ErrMissingPrefix    = errors.New("cashaddr prefix is missing")
+	ErrInvalidPrefix    = errors.New("invalid cashaddr prefix")
+	ErrInvalidCharacter = errors.New("invalid cashaddr character")
+	ErrInvalidChecksum  = errors.New("invalid cashaddr checksum")
+	ErrInvalidLength    = errors.New("invalid cashaddr payload length")
+	ErrInvalidVersion   = errors.New("invalid cashaddr version byte")
+)
+
+// sizeCodes are the size bits of the version byte for each hash length
+var sizeCodes = map[int]byte{20: 0, 24: 1, 28: 2, 32: 3, 40: 4, 48: 5, 56: 6, 64: 7}
+
+// Encode returns the cashaddr of the payload of type t with the prefix
+func Encode(prefix string, t AddrType, payload []byte) (string, error) {
+	if prefix == "" || strings.ToLower(prefix) != prefix {
+		return "", fmt.Errorf("%w: `%v`", ErrInvalidPrefix, prefix)
+	}
+	version := byte(t) << 3
+	if t != P2PKT {
+		sizeCode, ok := sizeCodes[len(payload)]
+		if !ok {
+			return "", fmt.Errorf("%w: %d bytes", ErrInvalidLength, len(payload))
+		}
+		version |= sizeCode
+	}
+	data, err := bech32.ConvertBits(append([]byte{version}, payload...), 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	checksum := polymod(append(append(expandPrefix(prefix), data...), make([]byte, checksumLength)...))
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	sb.WriteByte(':')
+	for _, d := range data {
+		sb.WriteByte(bech32.Charset[d])
+	}
+	for i := 0; i < checksumLength; i++ {
+		sb.WriteByte(bech32.Charset[checksum>>uint(5*(checksumLength-1-i))&31])
+	}
+	return sb.String(), nil
+}
+
+// Decode returns the prefix, the type and the payload of addr. Addresses
+// without prefix are decoded with defaultPrefix, empty to require one.
+func Decode(addr, defaultPrefix string) (string, AddrType, []byte, error) {
+	lower := strings.ToLower(addr)
+	if lower != addr && strings.ToUpper(addr) != addr {
+		return "", 0, nil, ErrMixedCase
+	}
+	prefix, body := defaultPrefix, lower
+	if sep := strings.IndexByte(lower, ':'); sep >= 0 {
+		prefix, body = lower[:sep], lower[sep+1:]
+	}
+	if prefix == "" {
+		return "", 0, nil, ErrMissingPrefix
+	}
+	if len(body) <= checksumLength {
+		return "", 0, nil, fmt.Errorf("%w: %d characters", ErrInvalidLength, len(body))
+	}
+	data := make([]byte, len(body))
+	for i, c := range []byte(body) {
+		v := strings.IndexByte(bech32.Charset, c)
+		if v < 0 {
+			return "", 0, nil, fmt.Errorf("%w: `%c` at position %d", ErrInvalidCharacter, c, len(addr)-len(body)+i)
+		}
+		data[i] = byte(v)
+	}
+	if polymod(append(expandPrefix(prefix), data...)) != 0 {
+		return "", 0, nil, ErrInvalidChecksum
+	}
+	decoded, err := bech32.ConvertBits(data[:len(data)-checksumLength], 5, 8, false)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if len(decoded) < 1 {
+		return "", 0, nil, ErrInvalidLength
+	}
+	version, payload := decoded[0], decoded[1:]
+	t := AddrType(version >> 3)
+	if t == P2PKT {
+		if version&7 != 0 {
+			return "", 0, nil, fmt.Errorf("%w: `%x`", ErrInvalidVersion, version)
+		}
+		return prefix, t, payload, nil
+	}
+	if version&0x80 != 0 {
+		return "", 0, nil, fmt.Errorf("%w: `%x`", ErrInvalidVersion, version)
+	}
+	if sizeCode, ok := sizeCodes[len(payload)]; !ok || sizeCode != version&7 {
+		return "", 0, nil, fmt.Errorf("%w: %d bytes", ErrInvalidLength, len(payload))
+	}
+	return prefix, t, payload, nil
+}
+
+// expandPrefix returns the lower 5 bits of the prefix characters followed
+// by the zero of the separator
+func expandPrefix(prefix string) []byte {
+	out := make([]byte, len(prefix)+1)
+	for i, c := range []byte(prefix) {
+		out[i] = c & 31
+	}
+	return out
+}
+
+var generator = [5]uint64{0x98f2bc8e61, 0x79b76d99e2, 0xf33e5fb3c4, 0xae2eabe2a8, 0x1e4f43e470}
+
+func polymod(values []byte) uint64 {
+	c := uint64(1)
+	for _, d := range values {
+		c0 := c >> 35
+		c = (c&0x07ffffffff)<<5 ^ uint64(d)
+		for i, g := range generator {
+			if c0>>uint(i)&1 == 1 {
+				c ^= g
+			}
+		}
+	}
+	return c ^ 1
+}
@@ -0,0 +1,94 @@
+package cashaddr
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Ref https://github.com/bitcoincashorg/bitcoincash.org/blob/master/spec/cashaddr.md#examples-of-address-translation
+func TestLegacy(t *testing.T) {
+	vectors := []struct {
+		legacy   string
+		cashAddr string
+	}{
+		{"1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu", "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a"},
+		{"1KXrWXciRDZUpQwQmuM1DbwsKDLYAYsVLR", "bitcoincash:qr95sy3j9xwd2ap32xkykttr4cvcu7as4y0qverfuy"},
+		{"16w1D5WRVKJuZUsSRzdLp9w3YGcgoxDXb", "bitcoincash:qqq3728yw0y47sqn6l2na30mcw6zm78dzqre909m2r"},
+		{"3CWFddi6m4ndiGyKqzYvsFYagqDLPVMTzC", "bitcoincash:ppm2qsznhks23z7629mms6s4cwef74vcwvn0h829pq"},
+		{"3LDsS579y7sruadqu11beEJoTjdFiFCdX4", "bitcoincash:pr95sy3j9xwd2ap32xkykttr4cvcu7as4yc93ky28e"},
+		{"31nwvkZwyPdgzjBJZXfDmSWsC4ZLKpYyUw", "bitcoincash:pqq3728yw0y47sqn6l2na30mcw6zm78dzq5ucqzc37"},
+	}
+	for _, v := range vectors {
+		addr, err := FromLegacy(v.legacy, PrefixBitcoinCash)
+		require.NoError(t, err, v.legacy)
+		assert.Equal(t, v.cashAddr, addr)
+		legacy, err := ToLegacy(v.cashAddr)
+		require.NoError(t, err, v.cashAddr)
+		assert.Equal(t, v.legacy, legacy)
+		// the prefix is optional and upper case is valid
+		legacy, err = ToLegacy(strings.ToUpper(strings.TrimPrefix(v.cashAddr, "bitcoincash:")))
+		require.NoError(t, err, v.cashAddr)
+		assert.Equal(t, v.legacy, legacy)
+	}
+}
+
+// Ref https://github.com/bitcoincashorg/bitcoincash.org/blob/master/spec/cashaddr.md#larger-test-vectors
+func TestEncodeSizes(t *testing.T) {
+	vectors := []struct {
+		prefix  string
+		t       AddrType
+		payload string
+		addr    string
+	}{
+		{"bitcoincash", P2PKH, "f5bf48b397dae70be82b3cca4793f8eb2b6cdac9", "bitcoincash:qr6m7j9njldwwzlg9v7v53unlr4jkmx6eylep8ekg2"},
+		{"bchtest", P2SH, "f5bf48b397dae70be82b3cca4793f8eb2b6cdac9", "bchtest:pr6m7j9njldwwzlg9v7v53unlr4jkmx6eyvwc0uz5t"},
+		{"pref", P2SH, "f5bf48b397dae70be82b3cca4793f8eb2b6cdac9", "pref:pr6m7j9njldwwzlg9v7v53unlr4jkmx6ey65nvtks5"},
+		{"bitcoincash", P2PKH, "7adbf6c17084bc86c1706827b41a56f5ca32865925e946ea", "bitcoincash:q9adhakpwzztepkpwp5z0dq62m6u5v5xtyj7j3h2ws4mr9g0"},
+		{"bitcoincash", P2PKH, "3a84f9cf51aae98a3bb3a78bf16a6183790b18719126325bfc0c075b", "bitcoincash:qgagf7w02x4wnz3mkwnchut2vxphjzccwxgjvvjmlsxqwkcw59jxxuz"},
+		{"bitcoincash", P2PKH, "3173ef6623c6b48ffd1a3dcc0cc6489b0a07bb47a37f47cfef4fe69de825c060", "bitcoincash:qvch8mmxy0rtfrlarg7ucrxxfzds5pamg73h7370aa87d80gyhqxq5nlegake"},
+	}
+	for _, v := range vectors {
+		payload, _ := hex.DecodeString(v.payload)
+		addr, err := Encode(v.prefix, v.t, payload)
+		require.NoError(t, err)
+		assert.Equal(t, v.addr, addr)
+		prefix, typ, decoded, err := Decode(v.addr, "")
+		require.NoError(t, err)
+		assert.Equal(t, v.prefix, prefix)
+		assert.Equal(t, v.t, typ)
+		assert.Equal(t, payload, decoded)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for addr, expected := range map[string]error{
+		"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6q": ErrInvalidChecksum,
+		"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6A": ErrMixedCase,
+		"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6i": ErrInvalidCharacter,
+		"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a":             ErrMissingPrefix,
+		"bchtest:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a":     ErrInvalidChecksum,
+	} {
+		_, _, _, err := Decode(addr, "")
+		assert.ErrorIs(t, err, expected, addr)
+	}
+	_, err := ToLegacy("nexa:nqtsq5g5" + strings.Repeat("q", 40))
+	assert.Error(t, err)
+}
+
+func TestAddrP2PKT(t *testing.T) {
+	pubKey, _ := hex.DecodeString("0330d54fd0dd420a6e5f8d3624f5f3482cae350f79d5f0753bf5beef9c2d91af3c")
+	addr, err := AddrP2PKT(PrefixNexa, pubKey)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(addr, "nexa:nqtsq5g5"), addr)
+	prefix, typ, payload, err := Decode(addr, "")
+	require.NoError(t, err)
+	assert.Equal(t, PrefixNexa, prefix)
+	assert.Equal(t, P2PKT, typ)
+	script, err := TemplateScriptP2PKT(pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte{23}, script...), payload)
+}
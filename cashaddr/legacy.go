@@ -0,0 +1,53 @@
+package cashaddr
+
+import (
+	"fmt"
+
+	"github.com/mearaj/bips/netparams"
+)
+
+// legacyParams are the networks of the legacy base58 addresses of each prefix
+var legacyParams = map[string]*netparams.Params{
+	PrefixBitcoinCash:        netparams.BitcoinCash,
+	PrefixBitcoinCashTestnet: netparams.BitcoinTestnet,
+	PrefixBitcoinCashRegtest: netparams.BitcoinRegtest,
+}
+
+// FromLegacy converts a legacy base58 P2PKH or P2SH address to the cashaddr
+// with the prefix, ex PrefixBitcoinCash
+func FromLegacy(legacy, prefix string) (string, error) {
+	params, ok := legacyParams[prefix]
+	if !ok {
+		return "", fmt.Errorf("%w: `%v` has no legacy addresses", ErrInvalidPrefix, prefix)
+	}
+	version, hash, err := params.DecodeBase58Check(legacy, 1)
+	if err != nil {
+		return "", err
+	}
+	switch string(version) {
+	case string(params.PubKeyHashPrefix):
+		return Encode(prefix, P2PKH, hash)
+	case string(params.ScriptHashPrefix):
+		return Encode(prefix, P2SH, hash)
+	}
+	return "", fmt.Errorf("%w: `%x`", netparams.ErrInvalidPrefix, version)
+}
+
+// ToLegacy converts a P2PKH or P2SH cashaddr to the legacy base58 address
+func ToLegacy(addr string) (string, error) {
+	prefix, t, hash, err := Decode(addr, PrefixBitcoinCash)
+	if err != nil {
+		return "", err
+	}
+	params, ok := legacyParams[prefix]
+	if !ok {
+		return "", fmt.Errorf("%w: `%v` has no legacy addresses", ErrInvalidPrefix, prefix)
+	}
+	switch t {
+	case P2PKH:
+		return params.AddrFromPubKeyHash(hash)
+	case P2SH:
+		return params.AddrFromScriptHash(hash)
+	}
+	return "", fmt.Errorf("%w: type %d has no legacy address", ErrInvalidVersion, t)
+}
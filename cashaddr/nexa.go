@@ -0,0 +1,26 @@
+package cashaddr
+
+import (
+	"github.com/mearaj/bips/bip32"
+)
+
+// TemplateScriptP2PKT returns the Nexa pay to public key template output
+// script of the compressed public key: no group, the well known template 1
+// and the hash160 of the script pushing the public key
+func TemplateScriptP2PKT(pubKey []byte) ([]byte, error) {
+	argsHash, err := bip32.HashRipeMD160onSha256(append([]byte{byte(len(pubKey))}, pubKey...))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{0x00, 0x51, byte(len(argsHash))}, argsHash...), nil
+}
+
+// AddrP2PKT returns the Nexa pay to public key template address of the
+// compressed public key, ex nexa:nqtsq5g5…
+func AddrP2PKT(prefix string, pubKey []byte) (string, error) {
+	script, err := TemplateScriptP2PKT(pubKey)
+	if err != nil {
+		return "", err
+	}
+	return Encode(prefix, P2PKT, append([]byte{byte(len(script))}, script...))
+}
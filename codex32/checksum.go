@@ -0,0 +1,107 @@
+package codex32
+
+// residue is a residue of the BCH codes of the codex32 checksums, 65 bits
+// for the short checksum and 75 bits for the long one, kept as its bits
+// above the 64th and its 64 lower bits
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0093.mediawiki#checksum
+type residue struct {
+	hi, lo uint64
+}
+
+// rsh returns the bits of r from the nth on
+func (r residue) rsh(n uint) uint64 {
+	if n >= 64 {
+		return r.hi >> (n - 64)
+	}
+	return r.hi<<(64-n) | r.lo>>n
+}
+
+// low returns the n lower bits of r
+func (r residue) low(n uint) residue {
+	if n >= 64 {
+		return residue{r.hi & (1<<(n-64) - 1), r.lo}
+	}
+	return residue{0, r.lo & (1<<n - 1)}
+}
+
+// bchCode is the BCH code of a codex32 checksum
+type bchCode struct {
+	// length is the number of checksum characters
+	length int
+	// bits is the size of the residue, 5 bits per checksum character
+	bits uint
+	// constant is the residue of a valid codex32 string
+	constant  residue
+	generator [5]residue
+}
+
+// shortCode is the checksum of codex32 strings of at most 93 characters,
+// its constant is 0x10ce0795c2fd1e62a
+var shortCode = &bchCode{
+	length:   13,
+	bits:     65,
+	constant: residue{0x1, 0x0ce0795c2fd1e62a},
+	generator: [5]residue{
+		{0x1, 0x9dc500ce73fde210},
+		{0x1, 0xbfae00def77fe529},
+		{0x1, 0xfbd920fffe7bee52},
+		{0x1, 0x739640bdeee3fdad},
+		{0x0, 0x7729a039cfc75f5a},
+	},
+}
+
+// longCode is the checksum of codex32 strings of 125 to 127 characters,
+// its constant is 0x43381e570bf4798ab26
+var longCode = &bchCode{
+	length:   15,
+	bits:     75,
+	constant: residue{0x433, 0x81e570bf4798ab26},
+	generator: [5]residue{
+		{0x3d5, 0x9d273535ea62d897},
+		{0x7a9, 0xbecb6361c6c51507},
+		{0x543, 0xf9b7e6c38d8a2a0e},
+		{0x0c5, 0x77eaeccf1990d13c},
+		{0x188, 0x7f74f8dc71b10651},
+	},
+}
+
+// codeOf returns the checksum code of a codex32 string of length
+// characters, nil when no code has strings of that length
+func codeOf(length int) *bchCode {
+	switch {
+	case length <= maxShortLength:
+		return shortCode
+	case length >= minLongLength && length <= maxLongLength:
+		return longCode
+	}
+	return nil
+}
+
+func (c *bchCode) polymod(values []byte) residue {
+	r := residue{0, 0x23181b3}
+	shift := c.bits - 5
+	for _, v := range values {
+		b := r.rsh(shift)
+		r = r.low(shift)
+		r = residue{r.hi<<5 | r.lo>>59, r.lo<<5 | uint64(v)}
+		for i, g := range c.generator {
+			if b>>uint(i)&1 == 1 {
+				r.hi ^= g.hi
+				r.lo ^= g.lo
+			}
+		}
+	}
+	return r
+}
+
+func (c *bchCode) checksum(data []byte) []byte {
+	values := append(append([]byte(nil), data...), make([]byte, c.length)...)
+	mod := c.polymod(values)
+	mod.hi ^= c.constant.hi
+	mod.lo ^= c.constant.lo
+	sum := make([]byte, c.length)
+	for i := range sum {
+		sum[i] = byte(mod.rsh(5*uint(c.length-1-i)) & 31)
+	}
+	return sum
+}
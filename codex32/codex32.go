@@ -0,0 +1,353 @@
+// Package codex32 is the Golang implementation of BIP93, codex32:
+// checksummed SSSS-aware BIP32 seeds.
+//
+// A codex32 string is "ms1" followed by a threshold digit, a 4 character
+// identifier, a share index, the payload holding the seed and a BCH
+// checksum, all in the bech32 character set. The checksum is 13 characters
+// long in strings of at most 93 characters and 15 characters long in
+// strings of 125 to 127 characters, the only ones long enough for seeds of
+// 63 and 64 bytes. Shares are points of a polynomial over GF(32), character
+// by character, and the secret is the share of index "s".
+//
+// The official BIP93 spec can be found at
+// https://github.com/bitcoin/bips/blob/master/bip-0093.mediawiki
+package codex32
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mearaj/bips/bip32"
+)
+
+const (
+	// HRP is the human readable part of every codex32 string
+	HRP = "ms"
+	// Charset is the bech32 character set, the index of a character is its value
+	Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	// SecretIndex is the share index of the unshared secret
+	SecretIndex = 's'
+	// MinSeedBytes and MaxSeedBytes bound the length of the master seed
+	MinSeedBytes = 16
+	MaxSeedBytes = 64
+
+	headerLength   = 6 // threshold, identifier and share index
+	maxShortLength = 93
+	minLongLength  = 125
+	maxLongLength  = 127
+	// shareIndices are the indices given to the shares by Split, in order
+	shareIndices = "acdefghjklmnpqrtuvwxyz023456789"
+)
+
+var (
+	ErrInvalidLength     = errors.New("invalid codex32 string length")
+	ErrInvalidHRP        = errors.New("codex32 string must start with ms1")
+	ErrMixedCase         = errors.New("codex32 string must not mix upper and lower case")
+	ErrInvalidCharacter  = errors.New("invalid bech32 character")
+	ErrInvalidChecksum   = errors.New("invalid codex32 checksum")
+	ErrInvalidThreshold  = errors.New("threshold must be 0 or between 2 and 9")
+	ErrInvalidShareIndex = errors.New("share index of an unshared secret must be s")
+	ErrInvalidIdentifier = errors.New("identifier must be 4 bech32 characters")
+	ErrInvalidSeedLength = errors.New("seed must be between 16 and 44 bytes, or of 63 or 64 bytes")
+	ErrInvalidShareCount = errors.New("share count must be between the threshold and 31")
+	ErrMismatchingShares = errors.New("shares have different thresholds, identifiers or lengths")
+	ErrDuplicateIndex    = errors.New("duplicate share index")
+	ErrNotEnoughShares   = errors.New("not enough shares to reach the threshold")
+)
+
+var charsetRev = func() [128]int8 {
+	var rev [128]int8
+	for i := range rev {
+		rev[i] = -1
+	}
+	for i, c := range Charset {
+		rev[c] = int8(i)
+	}
+	return rev
+}()
+
+// Codex32 is a decoded codex32 string
+type Codex32 struct {
+	// data holds the values of every character after "ms1", checksum included
+	data []byte
+}
+
+// Parse decodes and validates the codex32 string s
+func Parse(s string) (*Codex32, error) {
+	code := codeOf(len(s))
+	if code == nil || len(s) < len(HRP)+1+headerLength+code.length {
+		return nil, ErrInvalidLength
+	}
+	lower := strings.ToLower(s)
+	if s != lower && s != strings.ToUpper(s) {
+		return nil, ErrMixedCase
+	}
+	if !strings.HasPrefix(lower, HRP+"1") {
+		return nil, ErrInvalidHRP
+	}
+	data := make([]byte, 0, len(lower)-len(HRP)-1)
+	for i, c := range lower[len(HRP)+1:] {
+		if c >= 128 || charsetRev[c] < 0 {
+			return nil, fmt.Errorf("%w: `%c` at position %d", ErrInvalidCharacter, c, len(HRP)+1+i)
+		}
+		data = append(data, byte(charsetRev[c]))
+	}
+	if code.polymod(data) != code.constant {
+		return nil, ErrInvalidChecksum
+	}
+	c := &Codex32{data: data}
+	k := c.Threshold()
+	if k == 1 || k > 9 {
+		return nil, ErrInvalidThreshold
+	}
+	if k == 0 && c.ShareIndex() != SecretIndex {
+		return nil, ErrInvalidShareIndex
+	}
+	// the payload is a seed of at least MinSeedBytes with at most 4 bits
+	// of padding
+	if n := len(c.payload()); n*5%8 >= 5 || n*5/8 < MinSeedBytes {
+		return nil, ErrInvalidLength
+	}
+	return c, nil
+}
+
+// NewSecret returns the unshared codex32 secret of seed with threshold k,
+// k is 0 when the secret isn't meant to be split
+func NewSecret(seed []byte, identifier string, k int) (*Codex32, error) {
+	if len(seed) < MinSeedBytes || len(seed) > MaxSeedBytes {
+		return nil, ErrInvalidSeedLength
+	}
+	if k == 1 || k < 0 || k > 9 {
+		return nil, ErrInvalidThreshold
+	}
+	header, err := newHeader(k, identifier, SecretIndex)
+	if err != nil {
+		return nil, err
+	}
+	secret := newCodex32(header, convertBits(seed, 8, 5))
+	// seeds of 45 to 62 bytes are too long for the short checksum and too
+	// short for the long one
+	if secret.code() == nil {
+		return nil, ErrInvalidSeedLength
+	}
+	return secret, nil
+}
+
+// Split returns count shares of seed, any k of them recover it
+func Split(seed []byte, identifier string, k, count int) ([]*Codex32, error) {
+	if k < 2 || k > 9 {
+		return nil, ErrInvalidThreshold
+	}
+	if count < k || count > len(shareIndices) {
+		return nil, ErrInvalidShareCount
+	}
+	secret, err := NewSecret(seed, identifier, k)
+	if err != nil {
+		return nil, err
+	}
+	// The first k-1 shares are random, the others are interpolated from
+	// them and the secret
+	points := []*Codex32{secret}
+	shares := make([]*Codex32, 0, count)
+	for i := 0; i < k-1; i++ {
+		random := make([]byte, len(secret.payload()))
+		if _, err = rand.Read(random); err != nil {
+			return nil, err
+		}
+		for j := range random {
+			random[j] &= 31
+		}
+		header, _ := newHeader(k, identifier, rune(shareIndices[i]))
+		share := newCodex32(header, random)
+		points = append(points, share)
+		shares = append(shares, share)
+	}
+	for i := k - 1; i < count; i++ {
+		share, err := Interpolate(points, rune(shareIndices[i]))
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+	return shares, nil
+}
+
+// Interpolate returns the share at index from threshold shares, or the
+// share itself when it is given
+func Interpolate(shares []*Codex32, index rune) (*Codex32, error) {
+	if index >= 128 || charsetRev[index] < 0 {
+		return nil, fmt.Errorf("%w: `%c`", ErrInvalidCharacter, index)
+	}
+	if len(shares) == 0 {
+		return nil, ErrNotEnoughShares
+	}
+	for _, s := range shares {
+		if s.ShareIndex() == index {
+			return &Codex32{data: append([]byte(nil), s.data...)}, nil
+		}
+	}
+	first := shares[0]
+	k := first.Threshold()
+	if k == 0 {
+		k = 1
+	}
+	if len(shares) < k {
+		return nil, ErrNotEnoughShares
+	}
+	shares = shares[:k]
+	seen := map[rune]bool{}
+	for _, s := range shares {
+		if s.Threshold() != first.Threshold() || s.Identifier() != first.Identifier() || len(s.data) != len(first.data) {
+			return nil, ErrMismatchingShares
+		}
+		if seen[s.ShareIndex()] {
+			return nil, ErrDuplicateIndex
+		}
+		seen[s.ShareIndex()] = true
+	}
+	x := byte(charsetRev[index])
+	result := make([]byte, len(first.data))
+	for i, s := range shares {
+		xi := s.data[5]
+		// Lagrange basis polynomial of share i evaluated at x
+		num, den := byte(1), byte(1)
+		for j, o := range shares {
+			if i != j {
+				num = gfMul(num, x^o.data[5])
+				den = gfMul(den, xi^o.data[5])
+			}
+		}
+		w := gfDiv(num, den)
+		for j, v := range s.data {
+			result[j] ^= gfMul(w, v)
+		}
+	}
+	return &Codex32{data: result}, nil
+}
+
+// Recover returns the master seed from the codex32 secret or from
+// threshold shares
+func Recover(shares []*Codex32) ([]byte, error) {
+	secret, err := Interpolate(shares, SecretIndex)
+	if err != nil {
+		return nil, err
+	}
+	return secret.Seed(), nil
+}
+
+// RecoverStrings is Recover parsing the codex32 strings first
+func RecoverStrings(strs []string) ([]byte, error) {
+	shares := make([]*Codex32, len(strs))
+	for i, s := range strs {
+		share, err := Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i+1, err)
+		}
+		shares[i] = share
+	}
+	return Recover(shares)
+}
+
+// NewMasterKey recovers the master seed from codex32 strings and returns
+// its bip32 master key
+func NewMasterKey(strs ...string) (*bip32.Key, error) {
+	seed, err := RecoverStrings(strs)
+	if err != nil {
+		return nil, err
+	}
+	return bip32.NewMasterKey(seed)
+}
+
+// Threshold is the number of shares needed to recover the secret,
+// 0 for an unshared secret
+func (c *Codex32) Threshold() int {
+	return int(Charset[c.data[0]] - '0')
+}
+
+// Identifier is the 4 characters shared by all the shares of a secret
+func (c *Codex32) Identifier() string {
+	return dataString(c.data[1:5])
+}
+
+// ShareIndex is the index character of the share, SecretIndex for the secret
+func (c *Codex32) ShareIndex() rune {
+	return rune(Charset[c.data[5]])
+}
+
+// Seed returns the payload as bytes, without the padding bits
+func (c *Codex32) Seed() []byte {
+	payload := c.payload()
+	seed := convertBits(payload, 5, 8)
+	return seed[:len(payload)*5/8]
+}
+
+// String encodes the codex32 string in lower case
+func (c *Codex32) String() string {
+	return HRP + "1" + dataString(c.data)
+}
+
+func (c *Codex32) payload() []byte {
+	return c.data[headerLength : len(c.data)-c.code().length]
+}
+
+// code returns the checksum code of the string
+func (c *Codex32) code() *bchCode {
+	return codeOf(len(HRP) + 1 + len(c.data))
+}
+
+func newHeader(k int, identifier string, index rune) ([]byte, error) {
+	identifier = strings.ToLower(identifier)
+	if len(identifier) != 4 {
+		return nil, ErrInvalidIdentifier
+	}
+	header := []byte{byte(charsetRev['0'+k])}
+	for _, c := range identifier {
+		if c >= 128 || charsetRev[c] < 0 {
+			return nil, ErrInvalidIdentifier
+		}
+		header = append(header, byte(charsetRev[c]))
+	}
+	return append(header, byte(charsetRev[index])), nil
+}
+
+// newCodex32 checksums header || payload with the short checksum when the
+// string fits in 93 characters, with the long checksum otherwise
+func newCodex32(header, payload []byte) *Codex32 {
+	data := append(append([]byte(nil), header...), payload...)
+	code := shortCode
+	if len(HRP)+1+len(data)+shortCode.length > maxShortLength {
+		code = longCode
+	}
+	return &Codex32{data: append(data, code.checksum(data)...)}
+}
+
+func dataString(data []byte) string {
+	var b strings.Builder
+	for _, v := range data {
+		b.WriteByte(Charset[v])
+	}
+	return b.String()
+}
+
+// convertBits regroups data of fromBits wide values into toBits wide
+// values, the last one being padded with zeros
+func convertBits(data []byte, fromBits, toBits uint) []byte {
+	var acc uint
+	var bits uint
+	out := make([]byte, 0, (uint(len(data))*fromBits+toBits-1)/toBits)
+	maxV := uint(1)<<toBits - 1
+	for _, v := range data {
+		acc = acc<<fromBits | uint(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxV))
+		}
+	}
+	if bits > 0 {
+		out = append(out, byte(acc<<(toBits-bits)&maxV))
+	}
+	return out
+}
@@ -0,0 +1,265 @@
+package codex32
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0093.mediawiki#test-vectors
+func TestVectors(t *testing.T) {
+	vectors := []struct {
+		shares []string
+		secret string
+	}{
+		{
+			shares: []string{"ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlw"},
+			secret: "318c6318c6318c6318c6318c6318c631",
+		},
+		{
+			shares: []string{
+				"MS12NAMEA320ZYXWVUTSRQPNMLKJHGFEDCAXRPP870HKKQRM",
+				"MS12NAMECACDEFGHJKLMNPQRSTUVWXYZ023FTR2GDZMPY6PN",
+			},
+			secret: "d1808e096b35b209ca12132b264662a5",
+		},
+		{
+			shares: []string{
+				"ms13cashsllhdmn9m42vcsamx24zrxgs3qqjzqud4m0d6nln",
+			},
+			secret: "ffeeddccbbaa99887766554433221100",
+		},
+		{
+			shares: []string{
+				"ms13casha320zyxwvutsrqpnmlkjhgfedca2a8d0zehn8a0t",
+				"ms13cashcacdefghjklmnpqrstuvwxyz023949xq35my48dr",
+				"ms13cashd0wsedstcdcts64cd7wvy4m90lm28w4ffupqs7rm",
+			},
+			secret: "ffeeddccbbaa99887766554433221100",
+		},
+		{
+			shares: []string{"ms10leetsllhdmn9m42vcsamx24zrxgs3qrl7ahwvhw4fnzrhve25gvezzyqqtum9pgv99ycma"},
+			secret: "ffeeddccbbaa99887766554433221100ffeeddccbbaa99887766554433221100",
+		},
+		{
+			shares: []string{"MS100C8VSM32ZXFGUHPCHTLUPZRY9X8GF2TVDW0S3JN54KHCE6MUA7LQPZYGSFJD6AN074RXVCEMLH8WU3TK925ACDEFGHJKLMNPQRSTUVWXY06FHPV80UNDVARHRAK"},
+			secret: "dc5423251cb87175ff8110c8531d0952d8d73e1194e95b5f19d6f9df7c01111104c9baecdfea8cccc677fb9ddc8aec5553b86e528bcadfdcc201c17c638c47e9",
+		},
+	}
+	for _, v := range vectors {
+		for _, s := range v.shares {
+			c, err := Parse(s)
+			require.NoError(t, err, s)
+			assert.Equal(t, strings.ToLower(s), c.String())
+		}
+		seed, err := RecoverStrings(v.shares)
+		assert.NoError(t, err)
+		assert.Equal(t, v.secret, hex.EncodeToString(seed))
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	var shares []*Codex32
+	for _, s := range []string{
+		"MS12NAMEA320ZYXWVUTSRQPNMLKJHGFEDCAXRPP870HKKQRM",
+		"MS12NAMECACDEFGHJKLMNPQRSTUVWXYZ023FTR2GDZMPY6PN",
+	} {
+		c, err := Parse(s)
+		require.NoError(t, err)
+		shares = append(shares, c)
+	}
+	share, err := Interpolate(shares, 'd')
+	assert.NoError(t, err)
+	assert.Equal(t, "ms12namedll4f8jlh4e5vdvuldlfxu2jhdnlsm97xvenrxeg", share.String())
+	secret, err := Interpolate(shares, SecretIndex)
+	assert.NoError(t, err)
+	assert.Equal(t, "ms12names6xqguzttxkeqnjsjzv4jv3nz5k3kwgsphuh6evw", secret.String())
+}
+
+func TestSplitAndRecover(t *testing.T) {
+	seed, _ := hex.DecodeString("ffeeddccbbaa99887766554433221100ffeeddccbbaa99887766554433221100")
+	shares, err := Split(seed, "leet", 3, 5)
+	require.NoError(t, err)
+	assert.Len(t, shares, 5)
+	for _, s := range shares {
+		parsed, err := Parse(s.String())
+		assert.NoError(t, err)
+		assert.Equal(t, "leet", parsed.Identifier())
+	}
+	recovered, err := Recover([]*Codex32{shares[4], shares[1], shares[2]})
+	assert.NoError(t, err)
+	assert.Equal(t, seed, recovered)
+
+	_, err = Recover(shares[:2])
+	assert.Equal(t, ErrNotEnoughShares, err)
+	_, err = Recover([]*Codex32{shares[0], shares[1], shares[1]})
+	assert.Equal(t, ErrDuplicateIndex, err)
+
+	key, err := NewMasterKey(shares[0].String(), shares[2].String(), shares[3].String())
+	assert.NoError(t, err)
+	assert.True(t, key.IsPrivate())
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse("ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlx")
+	assert.Equal(t, ErrInvalidChecksum, err)
+	_, err = Parse("Ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlw")
+	assert.Equal(t, ErrMixedCase, err)
+	_, err = Parse("ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlb")
+	assert.ErrorIs(t, err, ErrInvalidCharacter)
+	_, err = Parse("ms1")
+	assert.Equal(t, ErrInvalidLength, err)
+	_, err = NewSecret(make([]byte, 15), "test", 0)
+	assert.Equal(t, ErrInvalidSeedLength, err)
+	_, err = NewSecret(make([]byte, 45), "test", 0)
+	assert.Equal(t, ErrInvalidSeedLength, err)
+	_, err = NewSecret(make([]byte, 65), "test", 0)
+	assert.Equal(t, ErrInvalidSeedLength, err)
+
+	long := "ms100c8vsm32zxfguhpchtlupzry9x8gf2tvdw0s3jn54khce6mua7lqpzygsfjd6an074rxvcemlh8wu3tk925acdefghjklmnpqrstuvwxy06fhpv80undvarhrak"
+	_, err = Parse(long[:len(long)-1] + "q")
+	assert.Equal(t, ErrInvalidChecksum, err)
+	_, err = Parse(long[:100])
+	assert.Equal(t, ErrInvalidLength, err)
+	_, err = Parse(long + "q")
+	assert.Equal(t, ErrInvalidLength, err)
+}
+
+// checksummed returns "ms1" || data || the checksum of data with code
+func checksummed(data string, code *bchCode) string {
+	values := make([]byte, len(data))
+	for i, c := range data {
+		values[i] = byte(charsetRev[c])
+	}
+	return HRP + "1" + data + dataString(code.checksum(values))
+}
+
+// TestInvalidStrings checks the invalid cases of the BIP93 test vectors, on
+// strings of the identifier faux that are valid but for the case tested
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0093.mediawiki#invalid-test-vectors
+func TestInvalidStrings(t *testing.T) {
+	x := func(n int) string { return strings.Repeat("x", n) }
+	short := checksummed("0fauxs"+x(26), shortCode)
+	long := checksummed("0fauxs"+x(103), longCode)
+	_, err := Parse(short)
+	require.NoError(t, err)
+	_, err = Parse(long)
+	require.NoError(t, err)
+
+	for _, v := range []struct {
+		description string
+		strs        []string
+		err         error
+	}{
+		{
+			"incorrect checksums",
+			[]string{
+				short[:len(short)-1] + "q",
+				short[:len(short)-13] + "q" + short[len(short)-12:],
+				short[:10] + "q" + short[11:],
+				long[:len(long)-1] + "q",
+				long[:len(long)-15] + "q" + long[len(long)-14:],
+			},
+			ErrInvalidChecksum,
+		},
+		{
+			"checksum of the wrong code for the length",
+			[]string{
+				checksummed("0fauxs"+x(26), longCode),
+				checksummed("0fauxs"+x(69), longCode),
+				checksummed("0fauxs"+x(105), shortCode),
+			},
+			ErrInvalidChecksum,
+		},
+		{
+			"too short, too long or with an incomplete group of more than 4 bits",
+			[]string{
+				checksummed("0fauxs"+x(25), shortCode),
+				checksummed("0fauxs"+x(27), shortCode),
+				checksummed("0fauxs"+x(72), shortCode),
+				checksummed("0fauxs"+x(102), longCode),
+				checksummed("0fauxs"+x(104), longCode),
+				checksummed("0fauxs"+x(105), longCode),
+				checksummed("0fauxs", shortCode),
+				"ms1",
+			},
+			ErrInvalidLength,
+		},
+		{
+			"invalid threshold",
+			[]string{
+				checksummed("xfauxa"+x(26), shortCode),
+				checksummed("pfauxs"+x(26), shortCode),
+				checksummed("xfauxs"+x(103), longCode),
+			},
+			ErrInvalidThreshold,
+		},
+		{
+			"threshold 0 with a share index other than s",
+			[]string{
+				checksummed("0fauxa"+x(26), shortCode),
+				checksummed("0fauxq"+x(26), shortCode),
+				checksummed("0fauxa"+x(103), longCode),
+			},
+			ErrInvalidShareIndex,
+		},
+		{
+			"mixed case",
+			[]string{
+				"MS1" + short[3:],
+				"ms1" + strings.ToUpper(short[3:]),
+				short[:4] + strings.ToUpper(short[4:8]) + short[8:],
+				strings.ToUpper(short[:len(short)-2]) + short[len(short)-2:],
+			},
+			ErrMixedCase,
+		},
+		{
+			"wrong human readable part or separator",
+			[]string{
+				"mx1" + short[3:],
+				"ms0" + short[3:],
+				"bc1" + long[3:],
+			},
+			ErrInvalidHRP,
+		},
+		{
+			"character out of the bech32 character set",
+			[]string{
+				short[:9] + "b" + short[10:],
+				short[:5] + "i" + short[6:],
+				short[:len(short)-1] + "o",
+			},
+			ErrInvalidCharacter,
+		},
+	} {
+		for _, s := range v.strs {
+			_, err := Parse(s)
+			assert.ErrorIs(t, err, v.err, "%s: %s", v.description, s)
+		}
+	}
+}
+
+func TestLongChecksum(t *testing.T) {
+	for _, n := range []int{63, 64} {
+		seed := make([]byte, n)
+		for i := range seed {
+			seed[i] = byte(i)
+		}
+		secret, err := NewSecret(seed, "test", 0)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, len(secret.String()), minLongLength)
+		parsed, err := Parse(secret.String())
+		require.NoError(t, err)
+		assert.Equal(t, seed, parsed.Seed())
+
+		shares, err := Split(seed, "test", 3, 5)
+		require.NoError(t, err)
+		strs := []string{shares[4].String(), shares[1].String(), shares[2].String()}
+		recovered, err := RecoverStrings(strs)
+		require.NoError(t, err)
+		assert.Equal(t, seed, recovered)
+	}
+}
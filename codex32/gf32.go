@@ -0,0 +1,34 @@
+package codex32
+
+// GF(32) with the polynomial x^5 + x^3 + 1 and 2 as generator,
+// the values of the bech32 characters being its elements
+var (
+	gfExp [31]byte
+	gfLog [32]byte
+)
+
+func init() {
+	v := byte(1)
+	for i := 0; i < 31; i++ {
+		gfExp[i] = v
+		gfLog[v] = byte(i)
+		v <<= 1
+		if v&32 != 0 {
+			v ^= 0x29
+		}
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[(int(gfLog[a])+int(gfLog[b]))%31]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[(int(gfLog[a])-int(gfLog[b])+31)%31]
+}
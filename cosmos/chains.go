@@ -0,0 +1,57 @@
+package cosmos
+
+import "fmt"
+
+// Presets of the well known chains, mostly with their own bip44 coin type,
+// many chains also use the Cosmos Hub one
+var (
+	CosmosHub   = &Chain{Name: "Cosmos Hub", CoinType: 118, HRP: "cosmos"}
+	Osmosis     = &Chain{Name: "Osmosis", CoinType: 118, HRP: "osmo"}
+	Juno        = &Chain{Name: "Juno", CoinType: 118, HRP: "juno"}
+	Akash       = &Chain{Name: "Akash", CoinType: 118, HRP: "akash"}
+	Stargaze    = &Chain{Name: "Stargaze", CoinType: 118, HRP: "stars"}
+	Regen       = &Chain{Name: "Regen", CoinType: 118, HRP: "regen"}
+	Terra       = &Chain{Name: "Terra", CoinType: 330, HRP: "terra"}
+	CryptoOrg   = &Chain{Name: "Crypto.org", CoinType: 394, HRP: "cro"}
+	Kava        = &Chain{Name: "Kava", CoinType: 459, HRP: "kava"}
+	Band        = &Chain{Name: "Band", CoinType: 494, HRP: "band"}
+	Secret      = &Chain{Name: "Secret Network", CoinType: 529, HRP: "secret"}
+	Agoric      = &Chain{Name: "Agoric", CoinType: 564, HRP: "agoric"}
+	Irisnet     = &Chain{Name: "Irisnet", CoinType: 566, HRP: "iaa", ValOperHRP: "iva", ValConsHRP: "ica"}
+	BitSong     = &Chain{Name: "BitSong", CoinType: 639, HRP: "bitsong"}
+	Persistence = &Chain{Name: "Persistence", CoinType: 750, HRP: "persistence"}
+	Desmos      = &Chain{Name: "Desmos", CoinType: 852, HRP: "desmos"}
+	LumNetwork  = &Chain{Name: "Lum Network", CoinType: 880, HRP: "lum"}
+	THORChain   = &Chain{Name: "THORChain", CoinType: 931, HRP: "thor"}
+	Coreum      = &Chain{Name: "Coreum", CoinType: 990, HRP: "core"}
+)
+
+// RegChains are all the chain presets, the first chain of a coin type is
+// its default
+var RegChains = []*Chain{
+	CosmosHub, Osmosis, Juno, Akash, Stargaze, Regen, Terra, CryptoOrg, Kava,
+	Band, Secret, Agoric, Irisnet, BitSong, Persistence, Desmos, LumNetwork,
+	THORChain, Coreum,
+}
+
+// ForCoinType returns the default chain of the bip44 coin type
+func ForCoinType(coinType uint32) (*Chain, error) {
+	for _, c := range RegChains {
+		if c.CoinType == coinType {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: coin type %d", ErrUnknownChain, coinType)
+}
+
+// ByHRP returns the chain and the address type of the human readable part
+func ByHRP(hrp string) (*Chain, AddrType, error) {
+	for _, c := range RegChains {
+		for _, t := range []AddrType{Account, ValOper, ValCons} {
+			if h, _ := c.HRPOf(t); h == hrp {
+				return c, t, nil
+			}
+		}
+	}
+	return nil, 0, fmt.Errorf("%w: `%v`", ErrUnknownChain, hrp)
+}
@@ -0,0 +1,143 @@
+// Package cosmos implements the bech32 addresses of the Cosmos SDK chains,
+// the RIPEMD160 of the SHA256 of the compressed secp256k1 public key with a
+// chain specific human readable part, and its validator operator and
+// consensus variants.
+//
+// Ref https://docs.cosmos.network/main/learn/beginner/accounts#addresses
+package cosmos
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/mearaj/bips/bech32"
+	"github.com/mearaj/bips/bip32"
+)
+
+// AddressLength is the length of an account address in bytes
+const AddressLength = 20
+
+// AddrType is the role of an address, which selects its human readable part
+type AddrType int
+
+const (
+	// Account is the address of a user account, ex cosmos1…
+	Account AddrType = iota
+	// ValOper is the address of a validator operator, ex cosmosvaloper1…
+	ValOper
+	// ValCons is the address of a validator consensus node, ex cosmosvalcons1…
+	ValCons
+)
+
+var (
+	ErrInvalidPubKey     = errors.New("invalid public key")
+	ErrInvalidLength     = errors.New("invalid address length")
+	ErrUnknownAddrType   = errors.New("unknown address type")
+	ErrUnknownChain      = errors.New("unknown cosmos chain")
+	ErrMismatchingPrefix = errors.New("address human readable part mismatch")
+)
+
+// Chain is a Cosmos SDK chain and its bech32 human readable parts
+type Chain struct {
+	Name     string
+	CoinType uint32
+	// HRP is the human readable part of the account addresses
+	HRP string
+	// ValOperHRP and ValConsHRP default to HRP followed by valoper and valcons
+	ValOperHRP string
+	ValConsHRP string
+}
+
+// HRPOf returns the human readable part of the addresses of type t
+func (c *Chain) HRPOf(t AddrType) (string, error) {
+	switch t {
+	case Account:
+		return c.HRP, nil
+	case ValOper:
+		if c.ValOperHRP != "" {
+			return c.ValOperHRP, nil
+		}
+		return c.HRP + "valoper", nil
+	case ValCons:
+		if c.ValConsHRP != "" {
+			return c.ValConsHRP, nil
+		}
+		return c.HRP + "valcons", nil
+	}
+	return "", fmt.Errorf("%w: %d", ErrUnknownAddrType, t)
+}
+
+// Address returns the address of type t of the public key on the chain
+func (c *Chain) Address(pubKey []byte, t AddrType) (string, error) {
+	hrp, err := c.HRPOf(t)
+	if err != nil {
+		return "", err
+	}
+	return Address(hrp, pubKey)
+}
+
+// Decode returns the address bytes of addr, whose human readable part must
+// be the one of the addresses of type t of the chain
+func (c *Chain) Decode(addr string, t AddrType) ([]byte, error) {
+	hrp, err := c.HRPOf(t)
+	if err != nil {
+		return nil, err
+	}
+	gotHRP, data, err := Decode(addr)
+	if err != nil {
+		return nil, err
+	}
+	if gotHRP != hrp {
+		return nil, fmt.Errorf("%w: `%v`", ErrMismatchingPrefix, gotHRP)
+	}
+	return data, nil
+}
+
+func (c *Chain) String() string {
+	return c.Name
+}
+
+// Address returns the bech32 address with the human readable part hrp of a
+// compressed or uncompressed public key
+func Address(hrp string, pubKey []byte) (string, error) {
+	hash, err := PubKeyHash(pubKey)
+	if err != nil {
+		return "", err
+	}
+	return AddressFromBytes(hrp, hash)
+}
+
+// AddressFromBytes returns the bech32 encoding of the address bytes
+func AddressFromBytes(hrp string, addr []byte) (string, error) {
+	data, err := bech32.ConvertBits(addr, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return bech32.Encode(hrp, data)
+}
+
+// Decode returns the human readable part and the bytes of a bech32 address
+func Decode(addr string) (string, []byte, error) {
+	hrp, data, err := bech32.Decode(addr)
+	if err != nil {
+		return "", nil, err
+	}
+	bs, err := bech32.ConvertBits(data, 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(bs) != AddressLength && len(bs) != 32 {
+		return "", nil, fmt.Errorf("%w: %d bytes", ErrInvalidLength, len(bs))
+	}
+	return hrp, bs, nil
+}
+
+// PubKeyHash returns the RIPEMD160 of the SHA256 of the compressed public key
+func PubKeyHash(pubKey []byte) ([]byte, error) {
+	key, err := secp256k1.ParsePubKey(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPubKey, err)
+	}
+	return bip32.HashRipeMD160onSha256(key.SerializeCompressed())
+}
@@ -0,0 +1,77 @@
+package cosmos
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/mearaj/bips/bech32"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// public key of m/44'/118'/0'/0/0 of the mnemonic abandon … about
+const testPubKey = "024f4e2ad99c34d60b9ba6283c9431a8418af8673212961f97a77b6377fcd05b62"
+
+func TestAddress(t *testing.T) {
+	pubKey, _ := hex.DecodeString(testPubKey)
+	addr, err := CosmosHub.Address(pubKey, Account)
+	require.NoError(t, err)
+	assert.Equal(t, "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4", addr)
+
+	hash, err := PubKeyHash(pubKey)
+	require.NoError(t, err)
+	for _, chain := range RegChains {
+		for _, addrType := range []AddrType{Account, ValOper, ValCons} {
+			addr, err := chain.Address(pubKey, addrType)
+			require.NoError(t, err)
+			hrp, _ := chain.HRPOf(addrType)
+			assert.True(t, strings.HasPrefix(addr, hrp+"1"), addr)
+			decoded, err := chain.Decode(addr, addrType)
+			require.NoError(t, err, addr)
+			assert.Equal(t, hash, decoded)
+			found, foundType, err := ByHRP(hrp)
+			require.NoError(t, err)
+			assert.Equal(t, chain.HRP, found.HRP)
+			assert.Equal(t, addrType, foundType)
+		}
+	}
+}
+
+func TestHRPOf(t *testing.T) {
+	hrp, _ := CosmosHub.HRPOf(ValOper)
+	assert.Equal(t, "cosmosvaloper", hrp)
+	hrp, _ = Osmosis.HRPOf(ValCons)
+	assert.Equal(t, "osmovalcons", hrp)
+	hrp, _ = Irisnet.HRPOf(ValOper)
+	assert.Equal(t, "iva", hrp)
+	_, err := CosmosHub.HRPOf(AddrType(3))
+	assert.ErrorIs(t, err, ErrUnknownAddrType)
+}
+
+func TestUncompressedPubKey(t *testing.T) {
+	pubKey, _ := hex.DecodeString(testPubKey)
+	key, err := secp256k1.ParsePubKey(pubKey)
+	require.NoError(t, err)
+	addr, err := Address(CosmosHub.HRP, key.SerializeUncompressed())
+	require.NoError(t, err)
+	assert.Equal(t, "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4", addr)
+	_, err = Address(CosmosHub.HRP, pubKey[1:])
+	assert.ErrorIs(t, err, ErrInvalidPubKey)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	pubKey, _ := hex.DecodeString(testPubKey)
+	addr, err := Osmosis.Address(pubKey, Account)
+	require.NoError(t, err)
+	_, err = CosmosHub.Decode(addr, Account)
+	assert.ErrorIs(t, err, ErrMismatchingPrefix)
+	_, err = CosmosHub.Decode("cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal5", Account)
+	assert.ErrorIs(t, err, bech32.ErrInvalidChecksum)
+	_, err = ForCoinType(0)
+	assert.ErrorIs(t, err, ErrUnknownChain)
+	chain, err := ForCoinType(118)
+	require.NoError(t, err)
+	assert.Equal(t, CosmosHub, chain)
+}
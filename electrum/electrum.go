@@ -0,0 +1,202 @@
+// Package electrum implements the mnemonic seeds of the Electrum wallet.
+//
+// Electrum seeds are not BIP39 mnemonics. Since version 2.0 the type of the
+// seed is encoded in the prefix of HMAC-SHA512("Seed version", mnemonic)
+// and the words carry no checksum. The seeds of older versions use their
+// own list of 1626 words, see OldWordList.
+//
+// Ref https://electrum.readthedocs.io/en/latest/seedphrase.html
+package electrum
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/mearaj/bips/bip32"
+	"github.com/mearaj/bips/bip39"
+	"github.com/mearaj/bips/bip39/wordlists"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// SeedType is the type of an Electrum seed
+type SeedType string
+
+const (
+	SeedTypeOld       SeedType = "old"
+	SeedTypeStandard  SeedType = "standard"
+	SeedTypeSegwit    SeedType = "segwit"
+	SeedType2FA       SeedType = "2fa"
+	SeedType2FASegwit SeedType = "2fa_segwit"
+)
+
+// seedPrefixes are the hex prefixes of HMAC-SHA512("Seed version", mnemonic)
+var seedPrefixes = []struct {
+	seedType SeedType
+	prefix   string
+}{
+	{SeedTypeStandard, "01"},
+	{SeedTypeSegwit, "100"},
+	{SeedType2FA, "101"},
+	{SeedType2FASegwit, "102"},
+}
+
+const (
+	// seedBits is the entropy of the seeds created by NewMnemonic, 12 words
+	seedBits       = 132
+	seedIteration  = 2048
+	seedSaltPrefix = "electrum"
+)
+
+var (
+	ErrInvalidMnemonic     = errors.New("not an electrum mnemonic")
+	ErrUnsupportedSeedType = errors.New("unsupported electrum seed type")
+)
+
+// SeedTypeOf returns the type of the mnemonic and false if it isn't an Electrum seed
+func SeedTypeOf(mnemonic string) (SeedType, bool) {
+	if IsOldMnemonic(mnemonic) {
+		return SeedTypeOld, true
+	}
+	mac := hmac.New(sha512.New, []byte("Seed version"))
+	mac.Write([]byte(normalizeText(mnemonic)))
+	version := hex.EncodeToString(mac.Sum(nil))
+	for _, p := range seedPrefixes {
+		if strings.HasPrefix(version, p.prefix) {
+			return p.seedType, true
+		}
+	}
+	return "", false
+}
+
+// IsMnemonicValid tells whether the mnemonic is an Electrum seed of any type
+func IsMnemonicValid(mnemonic string) bool {
+	_, ok := SeedTypeOf(mnemonic)
+	return ok
+}
+
+// NewMnemonic returns a new random 12 words seed of seedType, which must be
+// SeedTypeStandard or SeedTypeSegwit. The words are taken from the english
+// bip39 word list, the seed is never a valid bip39 or old Electrum mnemonic.
+func NewMnemonic(seedType SeedType) (string, error) {
+	if seedType != SeedTypeStandard && seedType != SeedTypeSegwit {
+		return "", ErrUnsupportedSeedType
+	}
+	n := big.NewInt(int64(len(wordlists.English)))
+	// at least 12 words, the most significant one being non zero
+	lowest := new(big.Int).Lsh(big.NewInt(1), seedBits-11)
+	entropy := new(big.Int)
+	for entropy.Cmp(lowest) < 0 {
+		var err error
+		entropy, err = rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), seedBits))
+		if err != nil {
+			return "", err
+		}
+	}
+	for {
+		entropy.Add(entropy, big.NewInt(1))
+		var words []string
+		for i, word := new(big.Int).Set(entropy), new(big.Int); i.Sign() > 0; {
+			i.DivMod(i, n, word)
+			words = append(words, wordlists.English[word.Int64()])
+		}
+		mnemonic := strings.Join(words, " ")
+		if t, _ := SeedTypeOf(mnemonic); t != seedType {
+			continue
+		}
+		if _, err := bip39.EntropyFromMnemonic(mnemonic); err == nil {
+			continue
+		}
+		return mnemonic, nil
+	}
+}
+
+// NewSeed stretches the mnemonic of a version 2 seed with PBKDF2 into
+// the 64 bytes seed of bip32.NewMasterKey
+func NewSeed(mnemonic, passphrase string) ([]byte, error) {
+	seedType, ok := SeedTypeOf(mnemonic)
+	if !ok {
+		return nil, ErrInvalidMnemonic
+	}
+	if seedType == SeedTypeOld {
+		return nil, ErrUnsupportedSeedType
+	}
+	return pbkdf2.Key(
+		[]byte(normalizeText(mnemonic)),
+		[]byte(seedSaltPrefix+normalizeText(passphrase)),
+		seedIteration, 64, sha512.New,
+	), nil
+}
+
+// DefaultDerivation is the path of the Electrum keystore root for seedType
+func DefaultDerivation(seedType SeedType) (bip32.Path, error) {
+	switch seedType {
+	case SeedTypeStandard:
+		return "m", nil
+	case SeedTypeSegwit:
+		return "m/0'", nil
+	}
+	return "", ErrUnsupportedSeedType
+}
+
+// NewMasterKey returns the root key of the Electrum keystore of a standard
+// or segwit seed with its path. Segwit keys are derived at m/0' and use the
+// zprv version bytes, standard keys are the bip32 master key.
+func NewMasterKey(mnemonic, passphrase string) (*bip32.Key, bip32.Path, error) {
+	seedType, ok := SeedTypeOf(mnemonic)
+	if !ok {
+		return nil, "", ErrInvalidMnemonic
+	}
+	path, err := DefaultDerivation(seedType)
+	if err != nil {
+		return nil, "", err
+	}
+	seed, err := NewSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, "", err
+	}
+	key, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, "", err
+	}
+	if seedType == SeedTypeSegwit {
+		child, err := key.NewChildKey(bip32.FirstHardenedChild)
+		if err != nil {
+			return nil, "", err
+		}
+		child.SetVersion(bip32.Bitcoinzprvzpub.PvtKeyFlagBytes())
+		key = &child
+	}
+	return key, path, nil
+}
+
+// normalizeText is Electrum's normalize_text: NFKD, lower case, no accents,
+// single spaces and no spaces between CJK characters
+func normalizeText(text string) string {
+	text = strings.ToLower(norm.NFKD.String(text))
+	text = strings.Join(strings.Fields(strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, text)), " ")
+	runes := []rune(text)
+	var b strings.Builder
+	for i, r := range runes {
+		if r == ' ' && i > 0 && i < len(runes)-1 && isCJK(runes[i-1]) && isCJK(runes[i+1]) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
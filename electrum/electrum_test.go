@@ -0,0 +1,86 @@
+package electrum
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/mearaj/bips/bip39"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ref https://github.com/spesmilo/electrum/blob/master/tests/test_wallet_vertical.py
+func TestSeedTypes(t *testing.T) {
+	vectors := []struct {
+		mnemonic string
+		seedType SeedType
+	}{
+		{"cycle rocket west magnet parrot shuffle foot correct salt library feed song", SeedTypeStandard},
+		{"bitter grass shiver impose acquire brush forget axis eager alone wine silver", SeedTypeSegwit},
+		{"powerful random nobody notice nothing important anyway look away hidden message over", SeedTypeOld},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", ""},
+	}
+	for _, v := range vectors {
+		seedType, ok := SeedTypeOf(v.mnemonic)
+		assert.Equal(t, v.seedType != "", ok, v.mnemonic)
+		assert.Equal(t, v.seedType, seedType, v.mnemonic)
+	}
+	seedType, _ := SeedTypeOf("  Cycle rocket west MAGNET parrot shuffle foot correct salt library feed song ")
+	assert.Equal(t, SeedTypeStandard, seedType)
+}
+
+func TestNewMasterKey(t *testing.T) {
+	key, path, err := NewMasterKey("bitter grass shiver impose acquire brush forget axis eager alone wine silver", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "m/0'", string(path))
+	assert.Equal(t, "zprvAZswDvNeJeha8qZ8g7efN3FXYVJLaEUsE9TW6qXDEbVe74AZ75c2sZFZXPNFzxnhChDQ89oC8C5AjWwHmH1HeRKE1c4kKBQAmjUDdKDUZw2", key.String())
+
+	key, path, err = NewMasterKey("cycle rocket west magnet parrot shuffle foot correct salt library feed song", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "m", string(path))
+	assert.Equal(t, "xprv9s21ZrQH143K32jECVM729vWgGq4mUDJCk1ozqAStTphzQtCTuoFmFafNoG1g55iCnBTXUzz3zWnDb5CVLGiFvmaZjuazHDL8a81cPQ8KL6", key.String())
+
+	_, _, err = NewMasterKey("powerful random nobody notice nothing important anyway look away hidden message over", "")
+	assert.Equal(t, ErrUnsupportedSeedType, err)
+	_, _, err = NewMasterKey("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	assert.Equal(t, ErrInvalidMnemonic, err)
+}
+
+func TestNewMnemonic(t *testing.T) {
+	for _, seedType := range []SeedType{SeedTypeStandard, SeedTypeSegwit} {
+		mnemonic, err := NewMnemonic(seedType)
+		assert.NoError(t, err)
+		assert.Len(t, strings.Fields(mnemonic), 12)
+		got, ok := SeedTypeOf(mnemonic)
+		assert.True(t, ok)
+		assert.Equal(t, seedType, got)
+		assert.False(t, bip39.IsMnemonicValid(mnemonic))
+	}
+	_, err := NewMnemonic(SeedTypeOld)
+	assert.Equal(t, ErrUnsupportedSeedType, err)
+}
+
+// Ref https://github.com/spesmilo/electrum/blob/master/tests/test_mnemonic.py
+func TestOldMnemonic(t *testing.T) {
+	mnemonic := "hardly point goal hallway patience key stone difference ready caught listen fact"
+	seed, err := OldMnemonicToSeed(mnemonic)
+	assert.NoError(t, err)
+	assert.Equal(t, "8edad31a95e7d59f8837667510d75a4d", seed)
+	encoded, err := OldMnemonicFromSeed(seed)
+	assert.NoError(t, err)
+	assert.Equal(t, mnemonic, encoded)
+
+	mpk, err := OldMasterPublicKey("powerful random nobody notice nothing important anyway look away hidden message over")
+	assert.NoError(t, err)
+	assert.Equal(t, "e9d4b7866dd1e91c862aebf62a49548c7dbf7bcc6e4b7b8c9da820c7737968df9c09d5a3e271dc814a29981f81b3faaf2737b551ef5dcc6189cf0f8252c442b3", hex.EncodeToString(mpk))
+
+	_, err = OldMnemonicToSeed("hardly point goal")
+	assert.ErrorIs(t, err, ErrInvalidOldMnemonic)
+	_, err = OldMnemonicFromSeed("8edad31a")
+	assert.Equal(t, ErrInvalidOldSeed, err)
+}
+
+func TestOldWordList(t *testing.T) {
+	assert.Len(t, OldWordList, 1626)
+	assert.Len(t, oldWordMap, 1626)
+}
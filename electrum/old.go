@@ -0,0 +1,97 @@
+package electrum
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// oldStretchRounds is the number of sha256 rounds applied to old seeds
+const oldStretchRounds = 100000
+
+var (
+	ErrInvalidOldMnemonic = errors.New("old electrum mnemonic must be 12 or 24 words of the old word list")
+	ErrInvalidOldSeed     = errors.New("old electrum seed must be 16 or 32 bytes")
+)
+
+// IsOldMnemonic tells whether mnemonic is an Electrum seed older than version 2.0
+func IsOldMnemonic(mnemonic string) bool {
+	_, err := OldMnemonicToSeed(mnemonic)
+	return err == nil
+}
+
+// OldMnemonicToSeed decodes an old mnemonic into its hex seed, every three
+// words encode 32 bits
+func OldMnemonicToSeed(mnemonic string) (string, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) != 12 && len(words) != 24 {
+		return "", ErrInvalidOldMnemonic
+	}
+	n := len(OldWordList)
+	var seed strings.Builder
+	for i := 0; i < len(words); i += 3 {
+		var w [3]int
+		for j := range w {
+			idx, ok := oldWordMap[words[i+j]]
+			if !ok {
+				return "", fmt.Errorf("%w: `%v`", ErrInvalidOldMnemonic, words[i+j])
+			}
+			w[j] = idx
+		}
+		x := w[0] + n*mod(w[1]-w[0], n) + n*n*mod(w[2]-w[1], n)
+		fmt.Fprintf(&seed, "%08x", x)
+	}
+	return seed.String(), nil
+}
+
+// OldMnemonicFromSeed encodes the hex seed of an old Electrum wallet
+func OldMnemonicFromSeed(seed string) (string, error) {
+	if _, err := hex.DecodeString(seed); err != nil || (len(seed) != 32 && len(seed) != 64) {
+		return "", ErrInvalidOldSeed
+	}
+	n := uint64(len(OldWordList))
+	words := make([]string, 0, len(seed)/8*3)
+	for i := 0; i < len(seed); i += 8 {
+		var x uint64
+		fmt.Sscanf(seed[i:i+8], "%08x", &x)
+		w1 := x % n
+		w2 := (x/n + w1) % n
+		w3 := (x/n/n + w2) % n
+		words = append(words, OldWordList[w1], OldWordList[w2], OldWordList[w3])
+	}
+	return strings.Join(words, " "), nil
+}
+
+// OldMasterPrivateKey returns the secret exponent of an old mnemonic,
+// its hex seed stretched by 100000 rounds of sha256.
+// Old seeds predate bip32, their keys are derived by Electrum itself.
+func OldMasterPrivateKey(mnemonic string) ([]byte, error) {
+	seed, err := OldMnemonicToSeed(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	x := []byte(seed)
+	for i := 0; i < oldStretchRounds; i++ {
+		h := sha256.Sum256(append(x, seed...))
+		x = h[:]
+	}
+	return x, nil
+}
+
+// OldMasterPublicKey returns the 64 bytes uncompressed public key, without
+// prefix, of OldMasterPrivateKey
+func OldMasterPublicKey(mnemonic string) ([]byte, error) {
+	pvtKey, err := OldMasterPrivateKey(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return secp256k1.PrivKeyFromBytes(pvtKey).PubKey().SerializeUncompressed()[1:], nil
+}
+
+func mod(a, n int) int {
+	return (a%n + n) % n
+}
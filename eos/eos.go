@@ -0,0 +1,174 @@
+// Package eos implements the string formats of the secp256k1 keys of EOS and
+// the other Antelope chains. Public keys are the base58 of the compressed key
+// and of its RIPEMD160 checksum, legacy EOS… or PUB_K1_… whose checksum
+// covers the K1 curve suffix too. Private keys are legacy WIF, uncompressed
+// with the 0x80 prefix, or PVT_K1_….
+//
+// Ref https://docs.eosnetwork.com/docs/latest/advanced-topics/keys-and-signatures/
+package eos
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/mearaj/bips/netparams"
+	"golang.org/x/crypto/ripemd160"
+)
+
+const (
+	// PubKeyPrefix is the prefix of legacy public keys
+	PubKeyPrefix = "EOS"
+	// PubKeyK1Prefix and PvtKeyK1Prefix are the prefixes of the K1 keys
+	PubKeyK1Prefix = "PUB_K1_"
+	PvtKeyK1Prefix = "PVT_K1_"
+	// WIFPrefix is the version byte of legacy private keys
+	WIFPrefix = 0x80
+	// ChecksumLength is the length of the checksums of keys
+	ChecksumLength = 4
+	// PvtKeyLength is the length of private keys
+	PvtKeyLength = 32
+
+	curveK1 = "K1"
+)
+
+var (
+	ErrInvalidPubKey   = errors.New("invalid public key")
+	ErrInvalidPvtKey   = errors.New("invalid private key")
+	ErrUnknownPrefix   = errors.New("unknown key prefix")
+	ErrInvalidBase58   = errors.New("invalid base58 string")
+	ErrInvalidChecksum = errors.New("invalid key checksum")
+)
+
+// wifParams are the base58check parameters of legacy private keys, those of
+// bitcoin
+var wifParams = &netparams.Params{Name: "EOS", WIFPrefix: []byte{WIFPrefix}}
+
+// PubKeyToString returns the legacy EOS… string of a compressed or
+// uncompressed public key
+func PubKeyToString(pubKey []byte) (string, error) {
+	compressed, err := compressPubKey(pubKey)
+	if err != nil {
+		return "", err
+	}
+	return PubKeyPrefix + encode(compressed, ""), nil
+}
+
+// PubKeyToK1String returns the PUB_K1_… string of a compressed or
+// uncompressed public key
+func PubKeyToK1String(pubKey []byte) (string, error) {
+	compressed, err := compressPubKey(pubKey)
+	if err != nil {
+		return "", err
+	}
+	return PubKeyK1Prefix + encode(compressed, curveK1), nil
+}
+
+// ParsePubKey returns the compressed public key of an EOS… or PUB_K1_… string
+func ParsePubKey(s string) ([]byte, error) {
+	var pubKey []byte
+	var err error
+	switch {
+	case strings.HasPrefix(s, PubKeyK1Prefix):
+		pubKey, err = decode(s[len(PubKeyK1Prefix):], curveK1)
+	case strings.HasPrefix(s, PubKeyPrefix):
+		pubKey, err = decode(s[len(PubKeyPrefix):], "")
+	default:
+		return nil, fmt.Errorf("%w: `%v`", ErrUnknownPrefix, s)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := secp256k1.ParsePubKey(pubKey); err != nil || len(pubKey) != secp256k1.PubKeyBytesLenCompressed {
+		return nil, ErrInvalidPubKey
+	}
+	return pubKey, nil
+}
+
+// PvtKeyToWIF returns the legacy WIF of a 32 bytes private key, 5…
+func PvtKeyToWIF(pvtKey []byte) (string, error) {
+	if err := validatePvtKey(pvtKey); err != nil {
+		return "", err
+	}
+	return wifParams.EncodeWIF(pvtKey, false)
+}
+
+// PvtKeyToK1String returns the PVT_K1_… string of a 32 bytes private key
+func PvtKeyToK1String(pvtKey []byte) (string, error) {
+	if err := validatePvtKey(pvtKey); err != nil {
+		return "", err
+	}
+	return PvtKeyK1Prefix + encode(pvtKey, curveK1), nil
+}
+
+// ParsePvtKey returns the private key of a legacy WIF or PVT_K1_… string
+func ParsePvtKey(s string) ([]byte, error) {
+	if strings.HasPrefix(s, PvtKeyK1Prefix) {
+		pvtKey, err := decode(s[len(PvtKeyK1Prefix):], curveK1)
+		if err != nil {
+			return nil, err
+		}
+		if err := validatePvtKey(pvtKey); err != nil {
+			return nil, err
+		}
+		return pvtKey, nil
+	}
+	pvtKey, compressed, err := wifParams.DecodeWIF(s)
+	switch {
+	case errors.Is(err, netparams.ErrInvalidChecksum):
+		return nil, ErrInvalidChecksum
+	case errors.Is(err, netparams.ErrInvalidBase58):
+		return nil, ErrInvalidBase58
+	case errors.Is(err, netparams.ErrInvalidPrefix):
+		return nil, fmt.Errorf("%w: `%v`", ErrUnknownPrefix, s)
+	case err != nil:
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPvtKey, err)
+	case compressed:
+		// legacy keys are always uncompressed
+		return nil, fmt.Errorf("%w: compressed WIF", ErrInvalidPvtKey)
+	}
+	return pvtKey, nil
+}
+
+// validatePvtKey verifies that pvtKey is a valid secp256k1 private key
+func validatePvtKey(pvtKey []byte) error {
+	if err := netparams.ValidatePvtKey(pvtKey); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPvtKey, err)
+	}
+	return nil
+}
+
+func compressPubKey(pubKey []byte) ([]byte, error) {
+	key, err := secp256k1.ParsePubKey(pubKey)
+	if err != nil {
+		return nil, ErrInvalidPubKey
+	}
+	return key.SerializeCompressed(), nil
+}
+
+// checksum returns the first 4 bytes of the RIPEMD160 of data and of the
+// curve suffix, empty for the legacy formats
+func checksum(data []byte, suffix string) []byte {
+	h := ripemd160.New()
+	h.Write(data)
+	h.Write([]byte(suffix))
+	return h.Sum(nil)[:ChecksumLength]
+}
+
+func encode(data []byte, suffix string) string {
+	return netparams.EncodeBase58(append(append([]byte(nil), data...), checksum(data, suffix)...), "")
+}
+
+func decode(s, suffix string) ([]byte, error) {
+	b, err := netparams.DecodeBase58(s, "")
+	if err != nil || len(b) <= ChecksumLength {
+		return nil, ErrInvalidBase58
+	}
+	data, sum := b[:len(b)-ChecksumLength], b[len(b)-ChecksumLength:]
+	if !bytes.Equal(checksum(data, suffix), sum) {
+		return nil, ErrInvalidChecksum
+	}
+	return data, nil
+}
@@ -0,0 +1,85 @@
+package eos
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/mearaj/bips/netparams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the development key of nodeos
+const (
+	testWIF      = "5KQwrPbwdL6PhXujxW37FSSQZ1JiwsST4cqQzDeyXtP79zkvFD3"
+	testPvtKeyK1 = "PVT_K1_2bfGi9rYsXQSXXTvJbDAPhHLQUojjaNLomdm3cEJ1XTzMqUt3V"
+	testPubKey   = "EOS6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5GDW5CV"
+	testPubKeyK1 = "PUB_K1_6MRyAjQq8ud7hVNYcfnVPJqcVpscN5So8BhtHuGYqET5BoDq63"
+)
+
+func TestPvtKey(t *testing.T) {
+	pvtKey, err := ParsePvtKey(testWIF)
+	require.NoError(t, err)
+	k1, err := ParsePvtKey(testPvtKeyK1)
+	require.NoError(t, err)
+	assert.Equal(t, pvtKey, k1)
+	s, err := PvtKeyToWIF(pvtKey)
+	require.NoError(t, err)
+	assert.Equal(t, testWIF, s)
+	s, err = PvtKeyToK1String(pvtKey)
+	require.NoError(t, err)
+	assert.Equal(t, testPvtKeyK1, s)
+
+	_, err = ParsePvtKey(testWIF[:len(testWIF)-1] + "4")
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+	_, err = ParsePvtKey(testPvtKeyK1[:len(testPvtKeyK1)-1] + "W")
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+	_, err = PvtKeyToWIF(pvtKey[1:])
+	assert.ErrorIs(t, err, ErrInvalidPvtKey)
+	_, err = ParsePvtKey("5" + testWIF[2:])
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+	_, err = ParsePvtKey(testWIF[:10] + "0" + testWIF[11:])
+	assert.ErrorIs(t, err, ErrInvalidBase58)
+}
+
+func TestPvtKeyRange(t *testing.T) {
+	zero := make([]byte, PvtKeyLength)
+	// the order of secp256k1
+	n, err := hex.DecodeString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141")
+	require.NoError(t, err)
+	for _, pvtKey := range [][]byte{zero, n} {
+		_, err = PvtKeyToWIF(pvtKey)
+		assert.ErrorIs(t, err, ErrInvalidPvtKey)
+		_, err = PvtKeyToK1String(pvtKey)
+		assert.ErrorIs(t, err, ErrInvalidPvtKey)
+
+		// encoded without the checks, the strings don't parse
+		wif, err := netparams.Bitcoin.EncodeBase58Check([]byte{WIFPrefix}, pvtKey)
+		require.NoError(t, err)
+		_, err = ParsePvtKey(wif)
+		assert.ErrorIs(t, err, ErrInvalidPvtKey)
+		_, err = ParsePvtKey(PvtKeyK1Prefix + encode(pvtKey, curveK1))
+		assert.ErrorIs(t, err, ErrInvalidPvtKey)
+	}
+}
+
+func TestPubKey(t *testing.T) {
+	pubKey, err := ParsePubKey(testPubKey)
+	require.NoError(t, err)
+	assert.Equal(t, "02c0ded2bc1f1305fb0faac5e6c03ee3a1924234985427b6167ca569d13df435cf", hex.EncodeToString(pubKey))
+	k1, err := ParsePubKey(testPubKeyK1)
+	require.NoError(t, err)
+	assert.Equal(t, pubKey, k1)
+	s, err := PubKeyToString(pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, testPubKey, s)
+	s, err = PubKeyToK1String(pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, testPubKeyK1, s)
+
+	// the checksums of the formats differ
+	_, err = ParsePubKey(PubKeyK1Prefix + testPubKey[len(PubKeyPrefix):])
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+	_, err = ParsePubKey("XYZ" + testPubKey[len(PubKeyPrefix):])
+	assert.ErrorIs(t, err, ErrUnknownPrefix)
+}
@@ -0,0 +1,121 @@
+// Package evm implements the addresses of Ethereum and the EVM chains, the
+// last 20 bytes of the keccak256 of the uncompressed public key, with the
+// EIP-55 mixed case checksum and its EIP-1191 chain aware variant.
+//
+// The official specs can be found at
+// https://eips.ethereum.org/EIPS/eip-55
+// https://eips.ethereum.org/EIPS/eip-1191
+package evm
+
+import (
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/crypto/sha3"
+)
+
+// AddressLength is the length of an address in bytes
+const AddressLength = 20
+
+// Chain ids of the chains which adopted the EIP-1191 checksum
+const (
+	ChainIDRSKMainnet = 30
+	ChainIDRSKTestnet = 31
+)
+
+var (
+	ErrInvalidPubKey   = errors.New("invalid public key")
+	ErrInvalidLength   = errors.New("address must be 40 hex characters")
+	ErrInvalidHex      = errors.New("address is not hex")
+	ErrInvalidChecksum = errors.New("invalid address checksum")
+)
+
+// Address is an EVM address
+type Address [AddressLength]byte
+
+// PubKeyToAddress returns the address of a compressed, uncompressed or
+// 64 bytes raw public key
+func PubKeyToAddress(pubKey []byte) (Address, error) {
+	var a Address
+	if len(pubKey) == 64 {
+		pubKey = append([]byte{secp256k1.PubKeyFormatUncompressed}, pubKey...)
+	}
+	key, err := secp256k1.ParsePubKey(pubKey)
+	if err != nil {
+		return a, ErrInvalidPubKey
+	}
+	// the coordinates are serialized as 32 bytes each, leading zeros included
+	h := sha3.NewLegacyKeccak256()
+	h.Write(key.SerializeUncompressed()[1:])
+	copy(a[:], h.Sum(nil)[12:])
+	return a, nil
+}
+
+// ParseAddress parses a 0x prefixed or bare hex address. All lower or all
+// upper case hex is accepted as is, mixed case must be a valid EIP-55 checksum.
+func ParseAddress(s string) (Address, error) {
+	return ParseAddressWithChainID(s, 0)
+}
+
+// ParseAddressWithChainID is ParseAddress validating mixed case against the
+// EIP-1191 checksum of chainID, 0 being the EIP-55 checksum
+func ParseAddressWithChainID(s string, chainID uint64) (Address, error) {
+	var a Address
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(digits) != 2*AddressLength {
+		return a, ErrInvalidLength
+	}
+	b, err := hex.DecodeString(digits)
+	if err != nil {
+		return a, ErrInvalidHex
+	}
+	copy(a[:], b)
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) &&
+		"0x"+digits != a.ChecksumHex(chainID) {
+		return a, ErrInvalidChecksum
+	}
+	return a, nil
+}
+
+// Hex returns the 0x prefixed EIP-55 checksummed address
+func (a Address) Hex() string {
+	return a.ChecksumHex(0)
+}
+
+// ChecksumHex returns the 0x prefixed address checksummed with EIP-1191 for
+// chainID, ex ChainIDRSKMainnet, or with EIP-55 for chainID 0. Chains that
+// didn't adopt EIP-1191 use Hex whatever their chain id.
+func (a Address) ChecksumHex(chainID uint64) string {
+	lower := hex.EncodeToString(a[:])
+	prefix := ""
+	if chainID != 0 {
+		prefix = strconv.FormatUint(chainID, 10) + "0x"
+	}
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(prefix + lower))
+	hash := h.Sum(nil)
+	out := []byte(lower)
+	for i, c := range out {
+		// the nibble of the hash at the character index decides the case
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0xf
+		}
+		if c > '9' && nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+// LowerHex returns the lower case hex of the address without 0x
+func (a Address) LowerHex() string {
+	return hex.EncodeToString(a[:])
+}
+
+func (a Address) String() string {
+	return a.Hex()
+}
@@ -0,0 +1,96 @@
+package evm
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Ref https://eips.ethereum.org/EIPS/eip-55#test-cases
+func TestEIP55(t *testing.T) {
+	for _, s := range []string{
+		"0x52908400098527886E0F7030069857D2E4169EE7",
+		"0x8617E340B3D01FA5F11F306F4090FD50E238070D",
+		"0xde709f2102306220921060314715629080e2fb77",
+		"0x27b1fdb04752bbc536007a920d24acb045561c26",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		a, err := ParseAddress(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, s, a.Hex())
+	}
+	_, err := ParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD")
+	assert.Equal(t, ErrInvalidChecksum, err)
+	_, err = ParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA")
+	assert.Equal(t, ErrInvalidLength, err)
+	_, err = ParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg")
+	assert.Equal(t, ErrInvalidHex, err)
+}
+
+// Ref https://eips.ethereum.org/EIPS/eip-1191#test-cases
+func TestEIP1191(t *testing.T) {
+	vectors := map[uint64][]string{
+		ChainIDRSKMainnet: {
+			"0x5aaEB6053f3e94c9b9a09f33669435E7ef1bEAeD",
+			"0xFb6916095cA1Df60bb79ce92cE3EA74c37c5d359",
+			"0xDBF03B407c01E7CD3cBea99509D93F8Dddc8C6FB",
+			"0xD1220A0Cf47c7B9BE7a2e6ba89F429762E7B9adB",
+		},
+		ChainIDRSKTestnet: {
+			"0x5aAeb6053F3e94c9b9A09F33669435E7EF1BEaEd",
+			"0xFb6916095CA1dF60bb79CE92ce3Ea74C37c5D359",
+			"0xdbF03B407C01E7cd3cbEa99509D93f8dDDc8C6fB",
+			"0xd1220a0CF47c7B9Be7A2E6Ba89f429762E7b9adB",
+		},
+	}
+	for chainID, addresses := range vectors {
+		for _, s := range addresses {
+			a, err := ParseAddressWithChainID(s, chainID)
+			assert.NoError(t, err, s)
+			assert.Equal(t, s, a.ChecksumHex(chainID))
+		}
+	}
+}
+
+func TestPubKeyToAddress(t *testing.T) {
+	for pvtKey, expected := range map[byte]string{
+		1: "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+		2: "0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF",
+	} {
+		key := make([]byte, 32)
+		key[31] = pvtKey
+		pubKey := secp256k1.PrivKeyFromBytes(key).PubKey()
+		a, err := PubKeyToAddress(pubKey.SerializeCompressed())
+		assert.NoError(t, err)
+		assert.Equal(t, expected, a.Hex())
+		b, err := PubKeyToAddress(pubKey.SerializeUncompressed())
+		assert.NoError(t, err)
+		assert.Equal(t, a, b)
+	}
+	_, err := PubKeyToAddress(make([]byte, 33))
+	assert.Equal(t, ErrInvalidPubKey, err)
+}
+
+// A public key whose x coordinate starts with a zero byte is hashed with
+// all its 64 bytes
+func TestPubKeyToAddressPadding(t *testing.T) {
+	key := make([]byte, 32)
+	for i := 1; ; i++ {
+		key[30], key[31] = byte(i>>8), byte(i)
+		pubKey := secp256k1.PrivKeyFromBytes(key).PubKey().SerializeUncompressed()
+		if pubKey[1] != 0 {
+			continue
+		}
+		a, err := PubKeyToAddress(pubKey[1:])
+		require.NoError(t, err)
+		b, err := PubKeyToAddress(secp256k1.PrivKeyFromBytes(key).PubKey().SerializeCompressed())
+		require.NoError(t, err)
+		assert.Equal(t, a, b)
+		break
+	}
+}
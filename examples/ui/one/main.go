@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"gioui.org/app"
 	"gioui.org/font/gofont"
 	"gioui.org/layout"
@@ -14,14 +15,21 @@ import (
 	"gioui.org/widget/material"
 	"gioui.org/x/component"
 	"gioui.org/x/outlay"
+	"github.com/mearaj/bips/address"
 	"github.com/mearaj/bips/bip32"
+	"github.com/mearaj/bips/bip39"
+	"github.com/mearaj/bips/bip44"
+	"github.com/mearaj/bips/netparams"
+	"github.com/mearaj/bips/qrcode"
 	"github.com/mearaj/bips/util"
 	"golang.org/x/exp/shiny/materialdesign/icons"
 	"image"
 	"image/color"
 	"log"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 )
 
 func main() {
@@ -43,6 +51,10 @@ type Flex = layout.Flex
 type KeyPathTab struct {
 	util.KeyPath
 	widget.Clickable
+	// addresses are the labels of every address form of the key and wif its
+	// WIF private key, computed once per key instead of on every frame
+	addresses []string
+	wif       string
 }
 type KeyPathTabs struct {
 	layout.List
@@ -72,6 +84,13 @@ var outputLeftBtn widget.Clickable
 var outputRightBtn widget.Clickable
 var viewLayout = layout.List{Axis: layout.Vertical}
 var tabsSlider Slider
+var btnExportSeedQR widget.Clickable
+var seedQR *qrcode.QRCode
+var compactSeedQR *qrcode.QRCode
+var seedQRImg widget.Image
+var compactSeedQRImg widget.Image
+var seedQRStatus string
+var seedQRDirField component.TextField
 
 var onKeyPathChange = func() {
 	str := "m"
@@ -81,36 +100,129 @@ var onKeyPathChange = func() {
 	keyPaths, _ := bps.DeriveBIP32Result(util.Path(str))
 	keyPathTabs.tabs = make([]KeyPathTab, 0)
 	for _, keyPath := range keyPaths {
-		keyPathTabs.tabs = append(keyPathTabs.tabs, KeyPathTab{KeyPath: keyPath})
+		keyPathTabs.tabs = append(keyPathTabs.tabs, KeyPathTab{
+			KeyPath:   keyPath,
+			addresses: addressLabels(keyPath),
+			wif:       wifOf(keyPath),
+		})
 	}
 	if keyPathTabs.selected >= len(keyPathTabs.tabs) {
 		keyPathTabs.selected = len(keyPathTabs.tabs) - 1
 	}
 }
 
+// rootKeyString serializes the root key with the checksum of the coin of
+// the derivation path, ex the Groestl-512 one of m/44'/17'/0'
+func rootKeyString(rootKey bip32.Key) string {
+	return rootKey.B58SerializeWith(bip32.VersionBytesOfPath(derivationPath, rootKey.GetVersion()))
+}
+
+var onSeedQRChange = func() {
+	seedQR, compactSeedQR = nil, nil
+	seedQRImg, compactSeedQRImg = widget.Image{}, widget.Image{}
+	seedQRStatus = ""
+	digits, err := bip39.MnemonicToSeedQR(mnemonic)
+	if err != nil {
+		return
+	}
+	seedQR, _ = qrcode.Encode([]byte(digits), qrcode.Low)
+	entropy, _ := bip39.MnemonicToCompactSeedQR(mnemonic)
+	compactSeedQR, _ = qrcode.EncodeMode(entropy, qrcode.Byte, qrcode.Low)
+	if seedQR != nil {
+		seedQRImg = widget.Image{Src: paint.NewImageOp(seedQR.Image(4))}
+	}
+	if compactSeedQR != nil {
+		compactSeedQRImg = widget.Image{Src: paint.NewImageOp(compactSeedQR.Image(4))}
+	}
+}
+
+// onExportSeedQRClicked writes the SeedQR PNGs into the directory of
+// seedQRDirField, never overwriting existing files
+var onExportSeedQRClicked = func() {
+	if seedQR == nil || compactSeedQR == nil {
+		return
+	}
+	dir := strings.TrimSpace(seedQRDirField.Text())
+	if dir == "" {
+		seedQRStatus = "Enter the directory to export to"
+		return
+	}
+	dir, err := filepath.Abs(dir)
+	if err == nil {
+		var info os.FileInfo
+		info, err = os.Stat(dir)
+		if err == nil && !info.IsDir() {
+			err = fmt.Errorf("%s isn't a directory", dir)
+		}
+	}
+	if err != nil {
+		seedQRStatus = err.Error()
+		return
+	}
+	files := []struct {
+		path string
+		q    *qrcode.QRCode
+	}{
+		{filepath.Join(dir, "seedqr.png"), seedQR},
+		{filepath.Join(dir, "compact-seedqr.png"), compactSeedQR},
+	}
+	for _, f := range files {
+		if _, err := os.Stat(f.path); err == nil {
+			seedQRStatus = fmt.Sprintf("%s already exists, not overwritten", f.path)
+			return
+		}
+	}
+	for _, f := range files {
+		bs, err := f.q.PNG(8)
+		if err == nil {
+			err = writeNewFile(f.path, bs)
+		}
+		if err != nil {
+			seedQRStatus = err.Error()
+			return
+		}
+	}
+	seedQRStatus = fmt.Sprintf("Saved %s and %s", files[0].path, files[1].path)
+}
+
+// writeNewFile writes data to the file at path, failing if it exists
+func writeNewFile(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
 var onAutoCreateMnemonicClicked = func() {
 	val := radioGroupMnemonicWords.Value
 	wordsCount, _ := strconv.Atoi(val)
 	mnemonic, _ = util.GenerateMnemonic(byte(wordsCount))
 	mnemonicField.SetText(mnemonic)
+	onSeedQRChange()
 	seed, _ = util.DeriveSeedFromMnemonic(mnemonic, mnemonicPassPhrase)
 	bip39SeedField.SetText(seed)
 	rootKey, _ := util.RootKeyFromSeed(seed)
 	bps.SetRootKey(*rootKey)
 	onKeyPathChange()
 	rootKey = bps.RootKey()
-	rootKeyStr = rootKey.String()
+	rootKeyStr = rootKeyString(*rootKey)
 	bip32RootKeyField.SetText(rootKeyStr)
 }
 var onMnemonicChange = func() {
 	mnemonic = mnemonicField.Text()
+	onSeedQRChange()
 	seed, _ = util.DeriveSeedFromMnemonic(mnemonic, mnemonicPassPhrase)
 	bip39SeedField.SetText(seed)
 	rootKey, _ := util.RootKeyFromSeed(seed)
 	bps.SetRootKey(*rootKey)
 	onKeyPathChange()
 	rootKey = bps.RootKey()
-	rootKeyStr = rootKey.String()
+	rootKeyStr = rootKeyString(*rootKey)
 	bip32RootKeyField.SetText(rootKeyStr)
 }
 
@@ -122,7 +234,7 @@ var onMnemonicPassphraseChange = func() {
 	bps.SetRootKey(*rootKey)
 	onKeyPathChange()
 	rootKey = bps.RootKey()
-	rootKeyStr = rootKey.String()
+	rootKeyStr = rootKeyString(*rootKey)
 	bip32RootKeyField.SetText(rootKeyStr)
 }
 
@@ -130,11 +242,12 @@ var onSeedChange = func() {
 	seed = bip39SeedField.Text()
 	mnemonic = ""
 	mnemonicField.SetText(mnemonic)
+	onSeedQRChange()
 	rootKey, _ := util.RootKeyFromSeed(seed)
 	bps.SetRootKey(*rootKey)
 	onKeyPathChange()
 	rootKey = bps.RootKey()
-	rootKeyStr = rootKey.String()
+	rootKeyStr = rootKeyString(*rootKey)
 	bip32RootKeyField.SetText(rootKeyStr)
 }
 
@@ -142,6 +255,7 @@ var onRootKeyStrChange = func() {
 	rootKeyStr = bip32RootKeyField.Text()
 	mnemonic = ""
 	mnemonicField.SetText(mnemonic)
+	onSeedQRChange()
 	seed = ""
 	bip39SeedField.SetText(seed)
 	rootKey, err := bip32.B58Deserialize(rootKeyStr)
@@ -149,7 +263,7 @@ var onRootKeyStrChange = func() {
 		bps.SetRootKey(rootKey)
 		onKeyPathChange()
 		rootKey = *bps.RootKey()
-		rootKeyStr = rootKey.String()
+		rootKeyStr = rootKeyString(rootKey)
 		bip32RootKeyField.SetText(rootKeyStr)
 	} else {
 		bps.SetRootKey(bip32.Key{})
@@ -160,7 +274,7 @@ var OnDerivationPathChange = func() {
 	derivationPath = util.Path(derivationStr).Formatted()
 	onKeyPathChange()
 	rootKey := *bps.RootKey()
-	rootKeyStr = rootKey.String()
+	rootKeyStr = rootKeyString(rootKey)
 	bip32RootKeyField.SetText(rootKeyStr)
 	// Set the appropriate BIP std (purpose component)
 }
@@ -181,6 +295,9 @@ func loop(w *app.Window) error {
 			if btnGenerateMnemonic.Clicked(gtx) {
 				onAutoCreateMnemonicClicked()
 			}
+			if btnExportSeedQR.Clicked(gtx) {
+				onExportSeedQRClicked()
+			}
 			if mnemonicField.Text() != mnemonic {
 				onMnemonicChange()
 			}
@@ -252,6 +369,45 @@ func loop(w *app.Window) error {
 						Rigid(func(gtx Gtx) Dim {
 							return mnemonicField.Layout(gtx, th, "Enter your mnemonic")
 						}),
+						Rigid(func(gtx Gtx) Dim {
+							if seedQR == nil || compactSeedQR == nil {
+								return Dim{}
+							}
+							flex := Flex{Axis: layout.Vertical}
+							return flex.Layout(gtx,
+								Rigid(layout.Spacer{Height: 8}.Layout),
+								Rigid(func(gtx Gtx) Dim {
+									flowWrap := outlay.FlowWrap{}
+									return flowWrap.Layout(gtx, 2, func(gtx Gtx, i int) Dim {
+										lbl, img := "SeedQR", seedQRImg
+										if i == 1 {
+											lbl, img = "CompactSeedQR", compactSeedQRImg
+										}
+										inset := layout.Inset{Right: 16}
+										return inset.Layout(gtx, func(gtx Gtx) Dim {
+											flex := Flex{Axis: layout.Vertical, Alignment: layout.Middle}
+											return flex.Layout(gtx,
+												Rigid(img.Layout),
+												Rigid(material.Label(th, 16, lbl).Layout),
+											)
+										})
+									})
+								}),
+								Rigid(layout.Spacer{Height: 8}.Layout),
+								Rigid(func(gtx Gtx) Dim {
+									return seedQRDirField.Layout(gtx, th, "Directory to export the SeedQR PNGs to")
+								}),
+								Rigid(layout.Spacer{Height: 8}.Layout),
+								Rigid(func(gtx Gtx) Dim {
+									flex := Flex{Alignment: layout.Middle}
+									return flex.Layout(gtx,
+										Rigid(material.Button(th, &btnExportSeedQR, "Export SeedQR PNG").Layout),
+										Rigid(layout.Spacer{Width: 16}.Layout),
+										Rigid(material.Label(th, 16, seedQRStatus).Layout),
+									)
+								}),
+							)
+						}),
 						Rigid(func(gtx Gtx) Dim {
 							return mnemonicPassphraseField.Layout(gtx, th,
 								"Enter your mnemonic passphrase(optional)")
@@ -385,21 +541,23 @@ func loop(w *app.Window) error {
 										return material.Label(th, 16, keyPath.Path.String()).Layout(gtx)
 									}),
 									Rigid(func(gtx Gtx) Dim {
-										return material.Label(th, 16, keyPath.Key.String()).Layout(gtx)
+										return material.Label(th, 16, keyPath.ExtendedKey()).Layout(gtx)
 									}),
 									Rigid(func(gtx Gtx) Dim {
-										publicKeyExtended := keyPath.Key.PublicKeyExtended()
-										return material.Label(th, 16, publicKeyExtended.String()).Layout(gtx)
+										return material.Label(th, 16, keyPath.ExtendedPubKey()).Layout(gtx)
 									}),
 									Rigid(func(gtx Gtx) Dim {
 										return material.Label(th, 16, keyPath.Key.PrivateKeyHex()).Layout(gtx)
 									}),
+									Rigid(func(gtx Gtx) Dim {
+										return layoutWIF(gtx, th, keyPath.wif)
+									}),
 									Rigid(func(gtx Gtx) Dim {
 										publicKeyExtended := keyPath.Key.PublicKeyExtended()
 										return material.Label(th, 16, publicKeyExtended.PublicKeyHex()).Layout(gtx)
 									}),
 									Rigid(func(gtx Gtx) Dim {
-										return material.Label(th, 16, keyPath.AddrHex()).Layout(gtx)
+										return layoutAddresses(gtx, th, keyPath.addresses)
 									}),
 								)
 							})
@@ -411,3 +569,87 @@ func loop(w *app.Window) error {
 		}
 	}
 }
+
+// coinOf returns the coin of the path, its third component, false when
+// it isn't the hardened component of a registered coin
+func coinOf(keyPath util.KeyPath) (bip44.Coin, bool) {
+	vals, err := keyPath.Path.ValuesAtDepth()
+	if err != nil || len(vals) < 3 {
+		return bip44.Coin{}, false
+	}
+	coin, ok := bip44.RegBip44CoinsPathCompToValMap[vals[2]]
+	return coin, ok
+}
+
+// wifOf returns the compressed WIF private key for the network of the coin
+// of the path, empty when the coin has no WIF
+func wifOf(keyPath util.KeyPath) string {
+	coin, ok := coinOf(keyPath)
+	if !ok {
+		return ""
+	}
+	params, err := netparams.ForCoin(coin)
+	if err != nil {
+		return ""
+	}
+	wif, err := keyPath.PvtKeyInWIF(params, true)
+	if err != nil {
+		return ""
+	}
+	return wif
+}
+
+// layoutWIF shows the WIF private key of the selected key
+func layoutWIF(gtx Gtx, th *material.Theme, wif string) Dim {
+	if wif == "" {
+		return Dim{}
+	}
+	return material.Label(th, 16, fmt.Sprintf("WIF: %s", wif)).Layout(gtx)
+}
+
+// addressLabels returns every address form registered for the coin of the
+// path, labelled with its encoding, and the raw forms of rawAddressLabels
+// for the paths of no registered coin
+func addressLabels(keyPath util.KeyPath) []string {
+	coin, ok := coinOf(keyPath)
+	if !ok {
+		return rawAddressLabels(keyPath)
+	}
+	labels := make([]string, 0)
+	for _, encoding := range address.Encodings(coin) {
+		addr, err := keyPath.Address(coin, encoding)
+		if err != nil {
+			continue
+		}
+		labels = append(labels, fmt.Sprintf("%s: %s", encoding, addr))
+	}
+	if len(labels) == 0 {
+		return rawAddressLabels(keyPath)
+	}
+	return labels
+}
+
+// rawAddressLabels returns the bitcoin P2PKH and the EVM addresses of the
+// key, whatever its path
+func rawAddressLabels(keyPath util.KeyPath) []string {
+	labels := make([]string, 0)
+	if addr, err := keyPath.AddrP2PKH(netparams.Bitcoin); err == nil {
+		labels = append(labels, fmt.Sprintf("%s: %s", bip32.P2PKH, addr))
+	}
+	if addr, err := keyPath.AddrEVM(); err == nil {
+		labels = append(labels, fmt.Sprintf("%s: %s", bip32.EVMHex, addr.Hex()))
+	}
+	return labels
+}
+
+// layoutAddresses lists the address labels of the selected key
+func layoutAddresses(gtx Gtx, th *material.Theme, labels []string) Dim {
+	children := make([]layout.FlexChild, len(labels))
+	for i, label := range labels {
+		label := label
+		children[i] = Rigid(func(gtx Gtx) Dim {
+			return material.Label(th, 16, label).Layout(gtx)
+		})
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
@@ -0,0 +1,130 @@
+// Package filecoin implements the secp256k1 addresses of Filecoin, the
+// protocol 1 addresses: the BLAKE2b-160 of the uncompressed public key and
+// the BLAKE2b-32 checksum of the protocol byte and the hash, base32 encoded
+// in lower case after the network prefix and the protocol, ex f1… or t1….
+//
+// Ref https://spec.filecoin.io/appendix/address/
+package filecoin
+
+import (
+	"bytes"
+	"encoding/base32"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// MainnetPrefix and TestnetPrefix are the network prefixes of addresses
+	MainnetPrefix = "f"
+	TestnetPrefix = "t"
+	// ProtocolSecp256k1 is the protocol of the addresses of secp256k1 keys
+	ProtocolSecp256k1 = 1
+	// PayloadLength is the length of the hash of the public key
+	PayloadLength = 20
+	// ChecksumLength is the length of the checksum of addresses
+	ChecksumLength = 4
+	// AddressLength is the length of the string of an address, 41
+	AddressLength = 2 + (8*(PayloadLength+ChecksumLength)+4)/5
+)
+
+var (
+	ErrInvalidPubKey   = errors.New("invalid public key")
+	ErrInvalidLength   = errors.New("invalid address length")
+	ErrUnknownNetwork  = errors.New("unknown address network")
+	ErrUnknownProtocol = errors.New("unsupported address protocol")
+	ErrInvalidEncoding = errors.New("invalid base32 encoding")
+	ErrInvalidChecksum = errors.New("invalid address checksum")
+	ErrInvalidPayload  = errors.New("payload must be 20 bytes")
+)
+
+// encoding is the lower case base32 of addresses, without padding
+var encoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// Address is a secp256k1 address of the mainnet or of the testnet
+type Address struct {
+	Payload [PayloadLength]byte
+	Testnet bool
+}
+
+// PubKeyToAddress returns the address of a compressed or uncompressed
+// public key
+func PubKeyToAddress(pubKey []byte, testnet bool) (Address, error) {
+	a := Address{Testnet: testnet}
+	key, err := secp256k1.ParsePubKey(pubKey)
+	if err != nil {
+		return a, ErrInvalidPubKey
+	}
+	copy(a.Payload[:], hash(key.SerializeUncompressed(), PayloadLength))
+	return a, nil
+}
+
+// NewAddress returns the address of a 20 bytes payload
+func NewAddress(payload []byte, testnet bool) (Address, error) {
+	a := Address{Testnet: testnet}
+	if len(payload) != PayloadLength {
+		return a, ErrInvalidPayload
+	}
+	copy(a.Payload[:], payload)
+	return a, nil
+}
+
+// ParseAddress parses a secp256k1 address of the mainnet or of the testnet,
+// ex f15ihq5ibzwki2b4ep2f46avlkrqzhpqgtga7pdrq
+func ParseAddress(s string) (Address, error) {
+	var a Address
+	if len(s) != AddressLength {
+		return a, fmt.Errorf("%w: `%v`", ErrInvalidLength, s)
+	}
+	switch s[:1] {
+	case MainnetPrefix:
+	case TestnetPrefix:
+		a.Testnet = true
+	default:
+		return a, fmt.Errorf("%w: `%v`", ErrUnknownNetwork, s[:1])
+	}
+	if s[1] != '0'+ProtocolSecp256k1 {
+		return a, fmt.Errorf("%w: `%c`", ErrUnknownProtocol, s[1])
+	}
+	data, err := encoding.DecodeString(s[2:])
+	// the re-encoding rejects the strings with non zero trailing bits
+	if err != nil || encoding.EncodeToString(data) != s[2:] {
+		return a, fmt.Errorf("%w: `%v`", ErrInvalidEncoding, s)
+	}
+	copy(a.Payload[:], data[:PayloadLength])
+	if !bytes.Equal(a.checksum(), data[PayloadLength:]) {
+		return a, ErrInvalidChecksum
+	}
+	return a, nil
+}
+
+// Checksum returns the BLAKE2b-32 checksum of data
+func Checksum(data []byte) []byte {
+	return hash(data, ChecksumLength)
+}
+
+func (a Address) checksum() []byte {
+	return Checksum(append([]byte{ProtocolSecp256k1}, a.Payload[:]...))
+}
+
+func (a Address) String() string {
+	prefix := MainnetPrefix
+	if a.Testnet {
+		prefix = TestnetPrefix
+	}
+	return fmt.Sprintf("%s%d%s", prefix, ProtocolSecp256k1,
+		encoding.EncodeToString(append(a.Payload[:], a.checksum()...)))
+}
+
+// hash returns the BLAKE2b hash of data of size bytes
+func hash(data []byte, size int) []byte {
+	h, err := blake2b.New(size, nil)
+	if err != nil {
+		// the sizes of this package are valid
+		panic(err)
+	}
+	h.Write(data)
+	return h.Sum(nil)
+}
@@ -0,0 +1,66 @@
+package filecoin
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Ref https://github.com/filecoin-project/go-address/blob/master/address_test.go
+func TestPubKeyToAddress(t *testing.T) {
+	vectors := []struct {
+		pubKey string
+		addr   string
+	}{
+		{"049402fac37e6432a416a3a0ca5426b5185ab3b24f6134efa25ce487c82d2e4e13bf452511e0d2245421f8613bc10d72fa216666a96c3bc13920d3ff233fd0bc05", "15ihq5ibzwki2b4ep2f46avlkrqzhpqgtga7pdrq"},
+		{"047687b910379bf28cbe3aea674b12000c6b7dba46ffc05f6c94fe2a22bbcc2602ff7f5c76f21ca55d36959152b0e1e887917c393576eef093f61ebd3ad06f7fda", "12fiakbhe2gwd5cnmrenekasyn6v5tnaxaqizq6a"},
+		{"04defdd01001efb86e01ded5ce34f847a73a14819ee641bcb60bb92993596f05dc2d605f2985f8d125812dac4163a396349b23c11cc2ff359de54be287ea62319b", "1wbxhu3ypkuo6eyp6hjx6davuelxaxrvwb2kuwva"},
+	}
+	for _, v := range vectors {
+		pubKey, _ := hex.DecodeString(v.pubKey)
+		for prefix, testnet := range map[string]bool{MainnetPrefix: false, TestnetPrefix: true} {
+			a, err := PubKeyToAddress(pubKey, testnet)
+			require.NoError(t, err)
+			assert.Equal(t, prefix+v.addr, a.String())
+			parsed, err := ParseAddress(prefix + v.addr)
+			require.NoError(t, err)
+			assert.Equal(t, a, parsed)
+		}
+		// the compressed key has the same address
+		key, err := secp256k1.ParsePubKey(pubKey)
+		require.NoError(t, err)
+		a, err := PubKeyToAddress(key.SerializeCompressed(), false)
+		require.NoError(t, err)
+		assert.Equal(t, MainnetPrefix+v.addr, a.String())
+	}
+	_, err := PubKeyToAddress([]byte{2, 3}, false)
+	assert.ErrorIs(t, err, ErrInvalidPubKey)
+}
+
+func TestParseAddress(t *testing.T) {
+	for addr, expected := range map[string]error{
+		"t1" + strings.Repeat("a", 39):              ErrInvalidChecksum,
+		"t1" + strings.Repeat("a", 40):              ErrInvalidLength,
+		"t1" + strings.Repeat("a", 38):              ErrInvalidLength,
+		"x15ihq5ibzwki2b4ep2f46avlkrqzhpqgtga7pdrq": ErrUnknownNetwork,
+		"f35ihq5ibzwki2b4ep2f46avlkrqzhpqgtga7pdrq": ErrUnknownProtocol,
+		"f15ihq5ibzwki2b4ep2f46avlkrqzhpqgtga7pdr1": ErrInvalidEncoding,
+		"f15ihq5ibzwki2b4ep2f46avlkrqzhpqgtga7pdrr": ErrInvalidEncoding,
+		"f15ihq5ibzwki2b4ep2f46avlkrqzhpqgtga7pdra": ErrInvalidChecksum,
+	} {
+		_, err := ParseAddress(addr)
+		assert.ErrorIs(t, err, expected, addr)
+	}
+	payload, _ := hex.DecodeString("ea0f0ea039b291a0f08fd179e0556a8c3277c0d3")
+	a, err := NewAddress(payload, false)
+	require.NoError(t, err)
+	parsed, err := ParseAddress(a.String())
+	require.NoError(t, err)
+	assert.Equal(t, a, parsed)
+	_, err = NewAddress(payload[1:], false)
+	assert.ErrorIs(t, err, ErrInvalidPayload)
+}
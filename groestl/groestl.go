@@ -0,0 +1,207 @@
+// Package groestl is the Golang implementation of the Grøstl-512 hash
+// function, the final round SHA-3 candidate used by Groestlcoin for its
+// base58check checksums.
+//
+// The official spec can be found at
+// https://www.groestl.info/Groestl.pdf
+package groestl
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+const (
+	// Size is the size of a Grøstl-512 digest in bytes
+	Size = 64
+	// BlockSize is the block size of Grøstl-512 in bytes
+	BlockSize = 128
+
+	rounds  = 14
+	columns = 16
+)
+
+// state is the 8 x 16 bytes matrix, the bytes of a block fill it column
+// after column
+type state [BlockSize]byte
+
+type digest struct {
+	h      state
+	buf    [BlockSize]byte
+	nbuf   int
+	blocks uint64
+}
+
+// New512 returns a new hash.Hash computing the Grøstl-512 digest
+func New512() hash.Hash {
+	d := &digest{}
+	d.Reset()
+	return d
+}
+
+// Sum512 returns the Grøstl-512 digest of data
+func Sum512(data []byte) [Size]byte {
+	var sum [Size]byte
+	d := New512()
+	d.Write(data)
+	copy(sum[:], d.Sum(nil))
+	return sum
+}
+
+func (d *digest) Reset() {
+	d.h = state{}
+	// the initial value is the output size in bits
+	binary.BigEndian.PutUint16(d.h[BlockSize-2:], Size*8)
+	d.nbuf = 0
+	d.blocks = 0
+}
+
+func (d *digest) Size() int { return Size }
+
+func (d *digest) BlockSize() int { return BlockSize }
+
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		c := copy(d.buf[d.nbuf:], p)
+		d.nbuf += c
+		p = p[c:]
+		if d.nbuf == BlockSize {
+			d.compress(d.buf[:])
+			d.nbuf = 0
+		}
+	}
+	return n, nil
+}
+
+func (d *digest) Sum(in []byte) []byte {
+	// work on a copy so that the caller can keep writing
+	c := *d
+	// pad with a one bit, zeros and the 64 bits count of blocks, padding
+	// included, to a multiple of the block size
+	padLength := BlockSize - c.nbuf
+	if padLength < 9 {
+		padLength += BlockSize
+	}
+	pad := make([]byte, padLength)
+	pad[0] = 0x80
+	blocks := c.blocks + uint64(c.nbuf+padLength)/BlockSize
+	binary.BigEndian.PutUint64(pad[padLength-8:], blocks)
+	c.Write(pad)
+
+	// output transformation, truncation to the last 512 bits of P(h) ^ h
+	x := c.h
+	permute(&x, false)
+	for i := range x {
+		x[i] ^= c.h[i]
+	}
+	return append(in, x[BlockSize-Size:]...)
+}
+
+// compress is f(h, m) = P(h ^ m) ^ Q(m) ^ h
+func (d *digest) compress(block []byte) {
+	var p, q state
+	for i := range p {
+		p[i] = d.h[i] ^ block[i]
+		q[i] = block[i]
+	}
+	permute(&p, false)
+	permute(&q, true)
+	for i := range d.h {
+		d.h[i] ^= p[i] ^ q[i]
+	}
+	d.blocks++
+}
+
+// shifts are the ShiftBytes left rotations of the rows of P and Q
+var shifts = [2][8]int{
+	{0, 1, 2, 3, 4, 5, 6, 11},
+	{1, 3, 5, 11, 0, 2, 4, 6},
+}
+
+// permute applies the rounds of the permutation Q if q, else of P
+func permute(s *state, q bool) {
+	shift := shifts[0]
+	if q {
+		shift = shifts[1]
+	}
+	for r := 0; r < rounds; r++ {
+		// AddRoundConstant
+		for j := 0; j < columns; j++ {
+			if q {
+				for i := 0; i < 7; i++ {
+					s[8*j+i] ^= 0xff
+				}
+				s[8*j+7] ^= byte(j<<4) ^ 0xff ^ byte(r)
+			} else {
+				s[8*j] ^= byte(j<<4) ^ byte(r)
+			}
+		}
+		// SubBytes, ShiftBytes and MixBytes with the tables of mixTables
+		var t state
+		for j := 0; j < columns; j++ {
+			var v uint64
+			for i := 0; i < 8; i++ {
+				v ^= mixTables[i][s[8*((j+shift[i])%columns)+i]]
+			}
+			binary.BigEndian.PutUint64(t[8*j:], v)
+		}
+		*s = t
+	}
+}
+
+var mixCoefficients = [8]byte{2, 2, 3, 4, 5, 3, 5, 7}
+
+// mixTables[i][x] is the column that MixBytes makes of the byte sbox[x] in
+// the row i, MixBytes multiplying each column by the circulant matrix of
+// mixCoefficients
+var mixTables [8][256]uint64
+
+func init() {
+	for i := 0; i < 8; i++ {
+		for x := 0; x < 256; x++ {
+			var v uint64
+			for row := 0; row < 8; row++ {
+				v = v<<8 | uint64(gfMul(mixCoefficients[(i-row+8)%8], sbox[x]))
+			}
+			mixTables[i][x] = v
+		}
+	}
+}
+
+// gfMul multiplies in GF(256) with the polynomial x^8 + x^4 + x^3 + x + 1
+func gfMul(a, b byte) byte {
+	var p byte
+	for b != 0 {
+		if b&1 != 0 {
+			p ^= a
+		}
+		hi := a & 0x80
+		a <<= 1
+		if hi != 0 {
+			a ^= 0x1b
+		}
+		b >>= 1
+	}
+	return p
+}
+
+// sbox is the AES S-box
+var sbox = [256]byte{
+	0x63, 0x7c, 0x77, 0x7b, 0xf2, 0x6b, 0x6f, 0xc5, 0x30, 0x01, 0x67, 0x2b, 0xfe, 0xd7, 0xab, 0x76,
+	0xca, 0x82, 0xc9, 0x7d, 0xfa, 0x59, 0x47, 0xf0, 0xad, 0xd4, 0xa2, 0xaf, 0x9c, 0xa4, 0x72, 0xc0,
+	0xb7, 0xfd, 0x93, 0x26, 0x36, 0x3f, 0xf7, 0xcc, 0x34, 0xa5, 0xe5, 0xf1, 0x71, 0xd8, 0x31, 0x15,
+	0x04, 0xc7, 0x23, 0xc3, 0x18, 0x96, 0x05, 0x9a, 0x07, 0x12, 0x80, 0xe2, 0xeb, 0x27, 0xb2, 0x75,
+	0x09, 0x83, 0x2c, 0x1a, 0x1b, 0x6e, 0x5a, 0xa0, 0x52, 0x3b, 0xd6, 0xb3, 0x29, 0xe3, 0x2f, 0x84,
+	0x53, 0xd1, 0x00, 0xed, 0x20, 0xfc, 0xb1, 0x5b, 0x6a, 0xcb, 0xbe, 0x39, 0x4a, 0x4c, 0x58, 0xcf,
+	0xd0, 0xef, 0xaa, 0xfb, 0x43, 0x4d, 0x33, 0x85, 0x45, 0xf9, 0x02, 0x7f, 0x50, 0x3c, 0x9f, 0xa8,
+	0x51, 0xa3, 0x40, 0x8f, 0x92, 0x9d, 0x38, 0xf5, 0xbc, 0xb6, 0xda, 0x21, 0x10, 0xff, 0xf3, 0xd2,
+	0xcd, 0x0c, 0x13, 0xec, 0x5f, 0x97, 0x44, 0x17, 0xc4, 0xa7, 0x7e, 0x3d, 0x64, 0x5d, 0x19, 0x73,
+	0x60, 0x81, 0x4f, 0xdc, 0x22, 0x2a, 0x90, 0x88, 0x46, 0xee, 0xb8, 0x14, 0xde, 0x5e, 0x0b, 0xdb,
+	0xe0, 0x32, 0x3a, 0x0a, 0x49, 0x06, 0x24, 0x5c, 0xc2, 0xd3, 0xac, 0x62, 0x91, 0x95, 0xe4, 0x79,
+	0xe7, 0xc8, 0x37, 0x6d, 0x8d, 0xd5, 0x4e, 0xa9, 0x6c, 0x56, 0xf4, 0xea, 0x65, 0x7a, 0xae, 0x08,
+	0xba, 0x78, 0x25, 0x2e, 0x1c, 0xa6, 0xb4, 0xc6, 0xe8, 0xdd, 0x74, 0x1f, 0x4b, 0xbd, 0x8b, 0x8a,
+	0x70, 0x3e, 0xb5, 0x66, 0x48, 0x03, 0xf6, 0x0e, 0x61, 0x35, 0x57, 0xb9, 0x86, 0xc1, 0x1d, 0x9e,
+	0xe1, 0xf8, 0x98, 0x11, 0x69, 0xd9, 0x8e, 0x94, 0x9b, 0x1e, 0x87, 0xe9, 0xce, 0x55, 0x28, 0xdf,
+	0x8c, 0xa1, 0x89, 0x0d, 0xbf, 0xe6, 0x42, 0x68, 0x41, 0x99, 0x2d, 0x0f, 0xb0, 0x54, 0xbb, 0x16,
+}
@@ -0,0 +1,18 @@
+package groestl
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSum512(t *testing.T) {
+	for msg, expected := range map[string]string{
+		"": "6d3ad29d279110eef3adbd66de2a0345a77baede1557f5d099fce0c03d6dc2ba8e6d4a6633dfbd66053c20faa87d1a11f39a7fbe4a6c2f009801370308fc4ad8",
+		"The quick brown fox jumps over the lazy dog": "badc1f70ccd69e0cf3760c3f93884289da84ec13c70b3d12a53a7a8a4a513f99715d46288f55e1dbf926e6d084a0538e4eebfc91cf2b21452921ccde9131718d",
+	} {
+		sum := Sum512([]byte(msg))
+		assert.Equal(t, expected, hex.EncodeToString(sum[:]), msg)
+	}
+}
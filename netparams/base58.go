@@ -0,0 +1,45 @@
+package netparams
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// Base58 alphabets, the same 58 characters in a different order
+const (
+	BitcoinAlphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	RippleAlphabet  = "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz"
+)
+
+// EncodeBase58 returns the base58 encoding of data with the alphabet, the
+// bitcoin one if empty
+func EncodeBase58(data []byte, alphabet string) string {
+	s := base58.Encode(data)
+	if alphabet == "" || alphabet == BitcoinAlphabet {
+		return s
+	}
+	// same radix, so the digits only need to be translated
+	out := []byte(s)
+	for i, c := range out {
+		out[i] = alphabet[strings.IndexByte(BitcoinAlphabet, c)]
+	}
+	return string(out)
+}
+
+// DecodeBase58 decodes s encoded with the alphabet, the bitcoin one if empty
+func DecodeBase58(s, alphabet string) ([]byte, error) {
+	if alphabet == "" {
+		alphabet = BitcoinAlphabet
+	}
+	translated := []byte(s)
+	for i, c := range translated {
+		digit := strings.IndexByte(alphabet, c)
+		if digit < 0 {
+			return nil, fmt.Errorf("%w: `%c` at position %d", ErrInvalidBase58, c, i)
+		}
+		translated[i] = BitcoinAlphabet[digit]
+	}
+	return base58.Decode(string(translated)), nil
+}
@@ -0,0 +1,33 @@
+package netparams
+
+import (
+	"github.com/mearaj/bips/bip32"
+)
+
+// versionBytes returns the VersionBytes whose checksum is the one of the
+// network, the serialization of the extended keys of p
+func (p *Params) versionBytes() bip32.VersionBytes {
+	return bip32.VersionBytes{Coin: p.Name, Checksum: p.Checksum}
+}
+
+// EncodeKey returns the base58 serialization of an extended key with the
+// checksum of the network. Several coins share the xprv and xpub versions,
+// so key.B58Serialize uses the bitcoin checksum, ex this is the one for the
+// Groestl-512 checksum of Groestlcoin keys.
+func (p *Params) EncodeKey(key *bip32.Key) (string, error) {
+	serialized, err := key.SerializeWith(p.versionBytes())
+	if err != nil {
+		return "", err
+	}
+	return EncodeBase58(serialized, p.Base58Alphabet), nil
+}
+
+// DecodeKey deserializes the base58 of an extended key verifying the
+// checksum of the network
+func (p *Params) DecodeKey(s string) (bip32.Key, error) {
+	data, err := DecodeBase58(s, p.Base58Alphabet)
+	if err != nil {
+		return bip32.Key{}, err
+	}
+	return bip32.DeserializeWith(data, p.versionBytes())
+}
@@ -0,0 +1,152 @@
+// Package netparams holds the address parameters of the networks of the
+// bip44 registered coins: the base58 version prefixes of pubkey hash,
+// script hash and WIF encodings, the bech32 human readable part, and the
+// hash and checksum functions of the network.
+package netparams
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/mearaj/bips/bip32"
+	"github.com/mearaj/bips/bip44"
+)
+
+// ChecksumLength is the length of the base58check checksum
+const ChecksumLength = 4
+
+var (
+	ErrUnsupportedCoin  = errors.New("coin has no registered network parameters")
+	ErrUnknownNetwork   = errors.New("unknown network")
+	ErrInvalidBase58    = errors.New("invalid base58 string")
+	ErrInvalidChecksum  = errors.New("invalid base58check checksum")
+	ErrInvalidPrefix    = errors.New("invalid version prefix")
+	ErrInvalidHash160   = errors.New("hash must be 20 bytes")
+	ErrPrefixNotDefined = errors.New("network doesn't define this prefix")
+)
+
+// HashFunc hashes data, ex bip32.HashRipeMD160onSha256
+type HashFunc func(data []byte) ([]byte, error)
+
+// Params are the address parameters of a network. A nil Hash160 or
+// Checksum is the bitcoin one.
+type Params struct {
+	// Name of the network, ex Bitcoin Testnet
+	Name string
+	// Coin is the bip44 coin of the network, all the testnets share the
+	// coin type 1
+	Coin bip44.Coin
+	// PubKeyHashPrefix is the version prefix of P2PKH addresses, 1 or 2 bytes
+	PubKeyHashPrefix []byte
+	// ScriptHashPrefix is the version prefix of P2SH addresses, 1 or 2 bytes
+	ScriptHashPrefix []byte
+	// WIFPrefix is the version prefix of WIF private keys
+	WIFPrefix []byte
+	// WIFKeyType follows WIFPrefix in WIF private keys, ex the signature
+	// type of Decred keys, such keys have no compressed flag
+	WIFKeyType []byte
+	// HRP is the bech32 human readable part, empty without segwit
+	HRP string
+	// Hash160 hashes public keys and scripts
+	Hash160 HashFunc
+	// Checksum returns the checksum of base58check payloads, at least 4 bytes
+	Checksum HashFunc
+	// Base58Alphabet is the alphabet of base58check strings, empty for
+	// BitcoinAlphabet
+	Base58Alphabet string
+}
+
+// HashOf hashes data with the Hash160 of the network
+func (p *Params) HashOf(data []byte) ([]byte, error) {
+	if p.Hash160 == nil {
+		return bip32.HashRipeMD160onSha256(data)
+	}
+	return p.Hash160(data)
+}
+
+// ChecksumOf returns the 4 bytes base58check checksum of data
+func (p *Params) ChecksumOf(data []byte) ([]byte, error) {
+	checksum := bip32.ChecksumDblSha256
+	if p.Checksum != nil {
+		checksum = p.Checksum
+	}
+	sum, err := checksum(data)
+	if err != nil {
+		return nil, err
+	}
+	return sum[:ChecksumLength], nil
+}
+
+// EncodeBase58Check returns the base58 encoding of prefix, payload and
+// their checksum
+func (p *Params) EncodeBase58Check(prefix, payload []byte) (string, error) {
+	data := append(append([]byte(nil), prefix...), payload...)
+	checksum, err := p.ChecksumOf(data)
+	if err != nil {
+		return "", err
+	}
+	return EncodeBase58(append(data, checksum...), p.Base58Alphabet), nil
+}
+
+// DecodeBase58Check verifies the checksum of s and returns its payload
+// after prefixLength version bytes, with the version bytes
+func (p *Params) DecodeBase58Check(s string, prefixLength int) ([]byte, []byte, error) {
+	data, err := DecodeBase58(s, p.Base58Alphabet)
+	if err != nil || len(data) < prefixLength+ChecksumLength || len(s) == 0 {
+		return nil, nil, ErrInvalidBase58
+	}
+	body, sum := data[:len(data)-ChecksumLength], data[len(data)-ChecksumLength:]
+	checksum, err := p.ChecksumOf(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bytes.Equal(checksum, sum) {
+		return nil, nil, ErrInvalidChecksum
+	}
+	return body[:prefixLength], body[prefixLength:], nil
+}
+
+// AddrP2PKH returns the pay to pubkey hash address of the serialized public key
+func (p *Params) AddrP2PKH(pubKey []byte) (string, error) {
+	hash, err := p.HashOf(pubKey)
+	if err != nil {
+		return "", err
+	}
+	return p.AddrFromPubKeyHash(hash)
+}
+
+// AddrFromPubKeyHash returns the P2PKH address of a 20 bytes pubkey hash
+func (p *Params) AddrFromPubKeyHash(hash []byte) (string, error) {
+	if len(hash) != 20 {
+		return "", ErrInvalidHash160
+	}
+	if len(p.PubKeyHashPrefix) == 0 {
+		return "", fmt.Errorf("%w: `%s` pubkey hash", ErrPrefixNotDefined, p.Name)
+	}
+	return p.EncodeBase58Check(p.PubKeyHashPrefix, hash)
+}
+
+// AddrP2SH returns the pay to script hash address of the redeem script
+func (p *Params) AddrP2SH(redeemScript []byte) (string, error) {
+	hash, err := p.HashOf(redeemScript)
+	if err != nil {
+		return "", err
+	}
+	return p.AddrFromScriptHash(hash)
+}
+
+// AddrFromScriptHash returns the P2SH address of a 20 bytes script hash
+func (p *Params) AddrFromScriptHash(hash []byte) (string, error) {
+	if len(hash) != 20 {
+		return "", ErrInvalidHash160
+	}
+	if len(p.ScriptHashPrefix) == 0 {
+		return "", fmt.Errorf("%w: `%s` script hash", ErrPrefixNotDefined, p.Name)
+	}
+	return p.EncodeBase58Check(p.ScriptHashPrefix, hash)
+}
+
+func (p *Params) String() string {
+	return p.Name
+}
@@ -0,0 +1,244 @@
+package netparams
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/mearaj/bips/bip32"
+	"github.com/mearaj/bips/bip39"
+	"github.com/stretchr/testify/assert"
+)
+
+// public key at m/44'/0'/0'/0/0 of the mnemonic "abandon ... about"
+const testPubKey = "03aaeb52dd7494c361049de67cc680e83ebcbbbdbeb13637d92cd845f70308af5e"
+
+func TestAddrP2PKH(t *testing.T) {
+	pubKey, _ := hex.DecodeString(testPubKey)
+	addr, err := Bitcoin.AddrP2PKH(pubKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", addr)
+
+	for params, start := range map[*Params]string{
+		BitcoinTestnet: "[mn]", Litecoin: "L", Dogecoin: "D", Dash: "X",
+		Zcash: "t1", ZcashTestnet: "tm", Vertcoin: "V", BitcoinGold: "G",
+		Decred: "Ds", DecredTestnet: "Ts", Groestlcoin: "F", GroestlcoinTestnet: "[mn]",
+	} {
+		addr, err := params.AddrP2PKH(pubKey)
+		assert.NoError(t, err)
+		assert.Regexp(t, "^"+start, addr, params.Name)
+		prefix, hash, err := params.DecodeBase58Check(addr, len(params.PubKeyHashPrefix))
+		assert.NoError(t, err)
+		assert.Equal(t, params.PubKeyHashPrefix, prefix)
+		expected, _ := params.HashOf(pubKey)
+		assert.Equal(t, expected, hash)
+	}
+}
+
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0049.mediawiki#test-vectors
+func TestAddrP2SH(t *testing.T) {
+	redeemScript, _ := hex.DecodeString("001438971f73930f6c141d977ac4fd4a727c854935b3")
+	addr, err := BitcoinTestnet.AddrP2SH(redeemScript)
+	assert.NoError(t, err)
+	assert.Equal(t, "2Mww8dCYPUpKHofjgcXcBCEGmniw9CoaiD2", addr)
+
+	for params, start := range map[*Params]string{
+		Bitcoin: "3", Litecoin: "M", Dogecoin: "[A9]", Zcash: "t3", ZcashTestnet: "t2",
+		Decred: "Dc", DecredTestnet: "Tc",
+	} {
+		addr, err := params.AddrP2SH(redeemScript)
+		assert.NoError(t, err)
+		assert.Regexp(t, "^"+start, addr, params.Name)
+	}
+
+	_, err = Bitcoin.AddrFromScriptHash(make([]byte, 19))
+	assert.Equal(t, ErrInvalidHash160, err)
+	_, err = (&Params{Name: "empty"}).AddrFromPubKeyHash(make([]byte, 20))
+	assert.ErrorIs(t, err, ErrPrefixNotDefined)
+}
+
+func TestDecodeBase58Check(t *testing.T) {
+	_, _, err := Bitcoin.DecodeBase58Check("1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabB", 1)
+	assert.Equal(t, ErrInvalidChecksum, err)
+	_, _, err = Bitcoin.DecodeBase58Check("1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeab0", 1)
+	assert.Equal(t, ErrInvalidBase58, err)
+}
+
+func TestRegParams(t *testing.T) {
+	for _, p := range RegParams {
+		assert.True(t, p.Coin.IsValid(), p.Name)
+		assert.Contains(t, CoinToParams[p.Coin], p)
+		found, err := ByName(p.Name)
+		assert.NoError(t, err)
+		assert.Equal(t, p, found)
+	}
+	p, err := ForCoinType(CoinTypeLitecoin)
+	assert.NoError(t, err)
+	assert.Equal(t, Litecoin, p)
+	p, err = ForCoinType(CoinTypeTestnet)
+	assert.NoError(t, err)
+	assert.Equal(t, BitcoinTestnet, p)
+	_, err = ForCoinType(60)
+	assert.ErrorIs(t, err, ErrUnsupportedCoin)
+}
+
+func TestBase58Alphabet(t *testing.T) {
+	data := []byte{0, 0, 1, 2, 3, 0xff}
+	for _, alphabet := range []string{"", BitcoinAlphabet, RippleAlphabet} {
+		decoded, err := DecodeBase58(EncodeBase58(data, alphabet), alphabet)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decoded)
+	}
+	assert.Equal(t, "rr", EncodeBase58([]byte{0, 0}, RippleAlphabet)[:2])
+	_, err := DecodeBase58("rl", RippleAlphabet)
+	assert.ErrorIs(t, err, ErrInvalidBase58)
+
+	// the genesis account of the XRP Ledger
+	pubKey, _ := hex.DecodeString("0330e7fc9d56bb25d6893ba3f317ae5bcf33b3291bd63db32654a313222f7fd020")
+	addr, err := Ripple.AddrP2PKH(pubKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", addr)
+}
+
+// Ref https://github.com/decred/base58/blob/master/base58check_test.go
+func TestDecredChecksum(t *testing.T) {
+	for payload, encoded := range map[string]string{
+		"":                           "Axk2WA6L",
+		"abc":                        "FmT72s9HXyp6",
+		"abcdefghijklmnopqrstuvwxyz": "2M5VSfthNqvveeGWTcKRgY4Rm258o4ZDKBZGkAQ799jp",
+	} {
+		s, err := Decred.EncodeBase58Check([]byte{20, 0}, []byte(payload))
+		assert.NoError(t, err)
+		assert.Equal(t, encoded, s)
+		_, decoded, err := Decred.DecodeBase58Check(encoded, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, payload, string(decoded))
+	}
+	hash, _ := hex.DecodeString("2789d58cfa0957d206f025c2af056fc8a77cebb0")
+	addr, err := Decred.AddrFromPubKeyHash(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, "DsUZxxoHJSty8DCfwfartwTYbuhmVct7tJu", addr)
+}
+
+// Ref https://en.bitcoin.it/wiki/Wallet_import_format
+func TestWIF(t *testing.T) {
+	pvtKey, _ := hex.DecodeString("0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d")
+	vectors := []struct {
+		params     *Params
+		compressed bool
+		wif        string
+	}{
+		{Bitcoin, false, "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ"},
+		{Bitcoin, true, "KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617"},
+	}
+	for _, v := range vectors {
+		wif, err := v.params.EncodeWIF(pvtKey, v.compressed)
+		assert.NoError(t, err)
+		assert.Equal(t, v.wif, wif, v.params.Name)
+		key, compressed, err := v.params.DecodeWIF(wif)
+		assert.NoError(t, err)
+		assert.Equal(t, pvtKey, key)
+		assert.Equal(t, v.compressed, compressed)
+		decoded, err := DecodeWIF(wif)
+		assert.NoError(t, err)
+		assert.Equal(t, pvtKey, decoded.PvtKey)
+		assert.Equal(t, v.compressed, decoded.Compressed)
+		assert.Contains(t, decoded.Networks, v.params)
+	}
+
+	// Decred keys are always compressed and start with Pm, Pt on the testnet
+	for params, start := range map[*Params]string{Decred: "Pm", DecredTestnet: "Pt"} {
+		for _, compressed := range []bool{false, true} {
+			wif, err := params.EncodeWIF(pvtKey, compressed)
+			assert.NoError(t, err)
+			assert.Regexp(t, "^"+start, wif)
+			decoded, err := DecodeWIF(wif)
+			assert.NoError(t, err)
+			assert.Equal(t, pvtKey, decoded.PvtKey)
+			assert.True(t, decoded.Compressed)
+			assert.Equal(t, []*Params{params}, decoded.Networks)
+		}
+	}
+
+	// the networks sharing a prefix share the key, Groestlcoin has its checksum
+	decoded, err := DecodeWIF("KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617")
+	assert.NoError(t, err)
+	assert.Contains(t, decoded.Networks, DigiByte)
+	assert.NotContains(t, decoded.Networks, Groestlcoin)
+	assert.NotContains(t, decoded.Networks, Litecoin)
+	wif, err := Groestlcoin.EncodeWIF(pvtKey, true)
+	assert.NoError(t, err)
+	decoded, err = DecodeWIF(wif)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Params{Groestlcoin}, decoded.Networks)
+	wif, err = Litecoin.EncodeWIF(pvtKey, true)
+	assert.NoError(t, err)
+	assert.Regexp(t, "^T", wif)
+
+	_, _, err = Litecoin.DecodeWIF("KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617")
+	assert.ErrorIs(t, err, ErrInvalidPrefix)
+	_, err = DecodeWIF("KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98618")
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+	_, err = DecodeWIF("KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP9861l")
+	assert.ErrorIs(t, err, ErrInvalidBase58)
+	unknown, _ := (&Params{WIFPrefix: []byte{0x01}}).EncodeWIF(pvtKey, true)
+	_, err = DecodeWIF(unknown)
+	assert.ErrorIs(t, err, ErrUnknownNetwork)
+	_, err = Bitcoin.EncodeWIF(make([]byte, PvtKeyLength), true)
+	assert.ErrorIs(t, err, ErrInvalidPvtKey)
+	_, err = Ripple.EncodeWIF(pvtKey, true)
+	assert.ErrorIs(t, err, ErrPrefixNotDefined)
+}
+
+// Groestlcoin shares the xprv version of bitcoin, its keys need the
+// checksum of the network
+func TestEncodeKey(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	key, err := bip32.NewMasterKey(seed)
+	assert.NoError(t, err)
+
+	// the m/44'/17'/0' account of the mnemonic "all all ... all" of the
+	// Trezor device tests, whose m/44'/17'/0'/0/0 address is the one the
+	// Trezor Groestlcoin wallet shows
+	master, err := bip32.NewMasterKey(bip39.NewSeed(strings.TrimSpace(strings.Repeat("all ", 12)), ""))
+	assert.NoError(t, err)
+	account := *master
+	for _, i := range []uint32{44, 17, 0} {
+		account, err = account.NewChildKey(i + bip32.FirstHardenedChild)
+		assert.NoError(t, err)
+	}
+	receiving, _ := account.NewChildKey(0)
+	receiving, _ = receiving.NewChildKey(0)
+	pubKey, _ := hex.DecodeString(receiving.PublicKeyHex())
+	addr, err := Groestlcoin.AddrP2PKH(pubKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "Fj62rBJi8LvbmWu2jzkaUX1NFXLEqDLoZM", addr)
+	accountPub := account.PublicKeyExtended()
+
+	for _, v := range []struct {
+		params   *Params
+		key      *bip32.Key
+		expected string
+	}{
+		{Bitcoin, key, "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi"},
+		{Groestlcoin, &accountPub, "xpub6DGa4qjCDqDNhLWUCEPJSETizUdexA2i5k3wUG2QboRNa4MNJV4k5XthorGcogStY5K5iJ6NHtsznNK599ir8PmA3d1jqEoZHsixDTddNA9"},
+	} {
+		s, err := v.params.EncodeKey(v.key)
+		assert.NoError(t, err)
+		assert.Equal(t, v.expected, s)
+		decoded, err := v.params.DecodeKey(s)
+		assert.NoError(t, err)
+		assert.Equal(t, *v.key, decoded)
+		// the default deserialization accepts the checksum of every coin
+		// of the version
+		decoded, err = bip32.B58Deserialize(s)
+		assert.NoError(t, err)
+		s, err = v.params.EncodeKey(&decoded)
+		assert.NoError(t, err)
+		assert.Equal(t, v.expected, s)
+	}
+	_, err = Groestlcoin.DecodeKey(accountPub.B58Serialize())
+	assert.ErrorIs(t, err, bip32.ErrInvalidChecksum)
+	_, err = Groestlcoin.EncodeKey(&bip32.Key{})
+	assert.ErrorIs(t, err, bip32.ErrEmptyKey)
+}
@@ -0,0 +1,290 @@
+package netparams
+
+import (
+	"fmt"
+
+	"github.com/mearaj/bips/bip32"
+	"github.com/mearaj/bips/bip44"
+)
+
+// Coin types of the registered networks
+const (
+	CoinTypeBitcoin     = 0
+	CoinTypeTestnet     = 1
+	CoinTypeLitecoin    = 2
+	CoinTypeDogecoin    = 3
+	CoinTypeReddcoin    = 4
+	CoinTypeDash        = 5
+	CoinTypePeercoin    = 6
+	CoinTypeNamecoin    = 7
+	CoinTypeGroestlcoin = 17
+	CoinTypeDigiByte    = 20
+	CoinTypeDecred      = 42
+	CoinTypeVertcoin    = 28
+	CoinTypeSyscoin     = 57
+	CoinTypeZcash       = 133
+	CoinTypeRipple      = 144
+	CoinTypeBitcoinCash = 145
+	CoinTypeBitcoinGold = 156
+	CoinTypePolis       = 1997
+)
+
+var (
+	Bitcoin = &Params{
+		Name:             "Bitcoin",
+		Coin:             coin(CoinTypeBitcoin),
+		PubKeyHashPrefix: []byte{0x00},
+		ScriptHashPrefix: []byte{0x05},
+		WIFPrefix:        []byte{0x80},
+		HRP:              "bc",
+	}
+	BitcoinTestnet = &Params{
+		Name:             "Bitcoin Testnet",
+		Coin:             coin(CoinTypeTestnet),
+		PubKeyHashPrefix: []byte{0x6f},
+		ScriptHashPrefix: []byte{0xc4},
+		WIFPrefix:        []byte{0xef},
+		HRP:              "tb",
+	}
+	BitcoinRegtest = &Params{
+		Name:             "Bitcoin Regtest",
+		Coin:             coin(CoinTypeTestnet),
+		PubKeyHashPrefix: []byte{0x6f},
+		ScriptHashPrefix: []byte{0xc4},
+		WIFPrefix:        []byte{0xef},
+		HRP:              "bcrt",
+	}
+	Litecoin = &Params{
+		Name:             "Litecoin",
+		Coin:             coin(CoinTypeLitecoin),
+		PubKeyHashPrefix: []byte{0x30},
+		ScriptHashPrefix: []byte{0x32},
+		WIFPrefix:        []byte{0xb0},
+		HRP:              "ltc",
+	}
+	LitecoinTestnet = &Params{
+		Name:             "Litecoin Testnet",
+		Coin:             coin(CoinTypeTestnet),
+		PubKeyHashPrefix: []byte{0x6f},
+		ScriptHashPrefix: []byte{0x3a},
+		WIFPrefix:        []byte{0xef},
+		HRP:              "tltc",
+	}
+	Dogecoin = &Params{
+		Name:             "Dogecoin",
+		Coin:             coin(CoinTypeDogecoin),
+		PubKeyHashPrefix: []byte{0x1e},
+		ScriptHashPrefix: []byte{0x16},
+		WIFPrefix:        []byte{0x9e},
+	}
+	DogecoinTestnet = &Params{
+		Name:             "Dogecoin Testnet",
+		Coin:             coin(CoinTypeTestnet),
+		PubKeyHashPrefix: []byte{0x71},
+		ScriptHashPrefix: []byte{0xc4},
+		WIFPrefix:        []byte{0xf1},
+	}
+	Reddcoin = &Params{
+		Name:             "Reddcoin",
+		Coin:             coin(CoinTypeReddcoin),
+		PubKeyHashPrefix: []byte{0x3d},
+		ScriptHashPrefix: []byte{0x05},
+		WIFPrefix:        []byte{0xbd},
+	}
+	Dash = &Params{
+		Name:             "Dash",
+		Coin:             coin(CoinTypeDash),
+		PubKeyHashPrefix: []byte{0x4c},
+		ScriptHashPrefix: []byte{0x10},
+		WIFPrefix:        []byte{0xcc},
+	}
+	DashTestnet = &Params{
+		Name:             "Dash Testnet",
+		Coin:             coin(CoinTypeTestnet),
+		PubKeyHashPrefix: []byte{0x8c},
+		ScriptHashPrefix: []byte{0x13},
+		WIFPrefix:        []byte{0xef},
+	}
+	Peercoin = &Params{
+		Name:             "Peercoin",
+		Coin:             coin(CoinTypePeercoin),
+		PubKeyHashPrefix: []byte{0x37},
+		ScriptHashPrefix: []byte{0x75},
+		WIFPrefix:        []byte{0xb7},
+	}
+	Namecoin = &Params{
+		Name:             "Namecoin",
+		Coin:             coin(CoinTypeNamecoin),
+		PubKeyHashPrefix: []byte{0x34},
+		ScriptHashPrefix: []byte{0x0d},
+		WIFPrefix:        []byte{0xb4},
+		HRP:              "nc",
+	}
+	DigiByte = &Params{
+		Name:             "DigiByte",
+		Coin:             coin(CoinTypeDigiByte),
+		PubKeyHashPrefix: []byte{0x1e},
+		ScriptHashPrefix: []byte{0x3f},
+		WIFPrefix:        []byte{0x80},
+		HRP:              "dgb",
+	}
+	Vertcoin = &Params{
+		Name:             "Vertcoin",
+		Coin:             coin(CoinTypeVertcoin),
+		PubKeyHashPrefix: []byte{0x47},
+		ScriptHashPrefix: []byte{0x05},
+		WIFPrefix:        []byte{0x80},
+		HRP:              "vtc",
+	}
+	Syscoin = &Params{
+		Name:             "Syscoin",
+		Coin:             coin(CoinTypeSyscoin),
+		PubKeyHashPrefix: []byte{0x3f},
+		ScriptHashPrefix: []byte{0x05},
+		WIFPrefix:        []byte{0x80},
+		HRP:              "sys",
+	}
+	// Zcash transparent addresses have 2 bytes prefixes, t1 and t3
+	Zcash = &Params{
+		Name:             "Zcash",
+		Coin:             coin(CoinTypeZcash),
+		PubKeyHashPrefix: []byte{0x1c, 0xb8},
+		ScriptHashPrefix: []byte{0x1c, 0xbd},
+		WIFPrefix:        []byte{0x80},
+	}
+	ZcashTestnet = &Params{
+		Name:             "Zcash Testnet",
+		Coin:             coin(CoinTypeTestnet),
+		PubKeyHashPrefix: []byte{0x1d, 0x25},
+		ScriptHashPrefix: []byte{0x1c, 0xba},
+		WIFPrefix:        []byte{0xef},
+	}
+	// Decred hashes with BLAKE-256, its addresses have 2 bytes prefixes, Ds
+	// and Dc, its WIF keys too and serialize the signature type of the key,
+	// 0x00 for secp256k1 ECDSA
+	// Ref https://github.com/decred/dcrd/blob/master/chaincfg/mainnetparams.go
+	Decred = &Params{
+		Name:             "Decred",
+		Coin:             coin(CoinTypeDecred),
+		PubKeyHashPrefix: []byte{0x07, 0x3f},
+		ScriptHashPrefix: []byte{0x07, 0x1a},
+		WIFPrefix:        []byte{0x22, 0xde},
+		WIFKeyType:       []byte{0x00},
+		Hash160:          bip32.HashRipeMD160onBlake256,
+		Checksum:         bip32.ChecksumDblBlake256,
+	}
+	DecredTestnet = &Params{
+		Name:             "Decred Testnet",
+		Coin:             coin(CoinTypeTestnet),
+		PubKeyHashPrefix: []byte{0x0f, 0x21},
+		ScriptHashPrefix: []byte{0x0e, 0xfc},
+		WIFPrefix:        []byte{0x23, 0x0e},
+		WIFKeyType:       []byte{0x00},
+		Hash160:          bip32.HashRipeMD160onBlake256,
+		Checksum:         bip32.ChecksumDblBlake256,
+	}
+	// Groestlcoin hashes its base58check checksums with Groestl-512
+	// Ref https://github.com/Groestlcoin/groestlcoin/blob/master/src/kernel/chainparams.cpp
+	Groestlcoin = &Params{
+		Name:             "Groestlcoin",
+		Coin:             coin(CoinTypeGroestlcoin),
+		PubKeyHashPrefix: []byte{0x24},
+		ScriptHashPrefix: []byte{0x05},
+		WIFPrefix:        []byte{0x80},
+		HRP:              "grs",
+		Checksum:         bip32.ChecksumDblGroestl512,
+	}
+	GroestlcoinTestnet = &Params{
+		Name:             "Groestlcoin Testnet",
+		Coin:             coin(CoinTypeTestnet),
+		PubKeyHashPrefix: []byte{0x6f},
+		ScriptHashPrefix: []byte{0xc4},
+		WIFPrefix:        []byte{0xef},
+		HRP:              "tgrs",
+		Checksum:         bip32.ChecksumDblGroestl512,
+	}
+	// BitcoinCash are the legacy base58 addresses of Bitcoin Cash, package
+	// cashaddr converts them to CashAddr
+	BitcoinCash = &Params{
+		Name:             "Bitcoin Cash",
+		Coin:             coin(CoinTypeBitcoinCash),
+		PubKeyHashPrefix: []byte{0x00},
+		ScriptHashPrefix: []byte{0x05},
+		WIFPrefix:        []byte{0x80},
+	}
+	BitcoinGold = &Params{
+		Name:             "Bitcoin Gold",
+		Coin:             coin(CoinTypeBitcoinGold),
+		PubKeyHashPrefix: []byte{0x26},
+		ScriptHashPrefix: []byte{0x17},
+		WIFPrefix:        []byte{0x80},
+		HRP:              "btg",
+	}
+	Polis = &Params{
+		Name:             "Polis",
+		Coin:             coin(CoinTypePolis),
+		PubKeyHashPrefix: []byte{0x37},
+		ScriptHashPrefix: []byte{0x38},
+		WIFPrefix:        []byte{0x3c},
+	}
+	// Ripple classic addresses are the base58check of the account id, the
+	// hash160 of the public key, with the ripple alphabet. XRP has no P2SH.
+	// Ref https://xrpl.org/docs/concepts/accounts/addresses
+	Ripple = &Params{
+		Name:             "Ripple",
+		Coin:             coin(CoinTypeRipple),
+		PubKeyHashPrefix: []byte{0x00},
+		Base58Alphabet:   RippleAlphabet,
+	}
+)
+
+// RegParams are all the registered network parameters, mainnets first
+var RegParams = []*Params{
+	Bitcoin, Litecoin, Dogecoin, Reddcoin, Dash, Peercoin, Namecoin, DigiByte,
+	Vertcoin, Syscoin, Zcash, BitcoinCash, BitcoinGold, Polis, Ripple, Decred,
+	Groestlcoin,
+	BitcoinTestnet, BitcoinRegtest, LitecoinTestnet, DogecoinTestnet, DashTestnet, ZcashTestnet,
+	DecredTestnet, GroestlcoinTestnet,
+}
+
+// EVMCoinTypes are the coin types of EVM chains sharing the Ethereum addresses
+var EVMCoinTypes = []uint32{60, 61, 137, 246, 889, 966, 1007, 9000, 9006}
+
+// CoinToParams are the network parameters of each coin, the testnet coin
+// maps to the testnets of all the coins
+var CoinToParams = map[bip44.Coin][]*Params{}
+
+func init() {
+	for _, p := range RegParams {
+		CoinToParams[p.Coin] = append(CoinToParams[p.Coin], p)
+	}
+}
+
+// ForCoin returns the network parameters of the coin, the first registered
+// network for the testnet coin
+func ForCoin(c bip44.Coin) (*Params, error) {
+	params, ok := CoinToParams[c]
+	if !ok {
+		return nil, fmt.Errorf("%w: `%v`", ErrUnsupportedCoin, c)
+	}
+	return params[0], nil
+}
+
+// ForCoinType is ForCoin with the bip44 coin type
+func ForCoinType(coinType uint32) (*Params, error) {
+	return ForCoin(bip44.RegBip44CoinsTypeToValMap[coinType])
+}
+
+// ByName returns the registered network parameters named name
+func ByName(name string) (*Params, error) {
+	for _, p := range RegParams {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: `%v`", ErrUnknownNetwork, name)
+}
+
+func coin(coinType uint32) bip44.Coin {
+	return bip44.RegBip44CoinsTypeToValMap[coinType]
+}
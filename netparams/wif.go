@@ -0,0 +1,129 @@
+package netparams
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/mearaj/bips/bip32"
+)
+
+const (
+	// PvtKeyLength is the length of the private keys of WIF strings
+	PvtKeyLength = 32
+	// compressedFlag follows the private keys of compressed public keys
+	compressedFlag = 0x01
+)
+
+var ErrInvalidPvtKey = errors.New("invalid WIF private key")
+
+// WIF is a decoded wallet import format private key
+type WIF struct {
+	PvtKey []byte
+	// Compressed tells if the key stands for its compressed public key
+	Compressed bool
+	// Networks are the networks of the prefix and checksum of the key,
+	// several networks share the 0x80 prefix
+	Networks []*Params
+}
+
+// EncodeWIF returns the wallet import format of a 32 bytes private key, with
+// the compressed flag if compressed. Keys with a WIFKeyType are always
+// compressed.
+func (p *Params) EncodeWIF(pvtKey []byte, compressed bool) (string, error) {
+	if len(p.WIFPrefix) == 0 {
+		return "", fmt.Errorf("%w: `%s` WIF", ErrPrefixNotDefined, p.Name)
+	}
+	if err := ValidatePvtKey(pvtKey); err != nil {
+		return "", err
+	}
+	payload := append(append([]byte(nil), p.WIFKeyType...), pvtKey...)
+	if compressed && len(p.WIFKeyType) == 0 {
+		payload = append(payload, compressedFlag)
+	}
+	return p.EncodeBase58Check(p.WIFPrefix, payload)
+}
+
+// DecodeWIF verifies the prefix and the checksum of wif and returns its
+// private key and compressed flag
+func (p *Params) DecodeWIF(wif string) ([]byte, bool, error) {
+	if len(p.WIFPrefix) == 0 {
+		return nil, false, fmt.Errorf("%w: `%s` WIF", ErrPrefixNotDefined, p.Name)
+	}
+	prefix, payload, err := p.DecodeBase58Check(wif, len(p.WIFPrefix))
+	if err != nil {
+		return nil, false, err
+	}
+	if !bytes.Equal(prefix, p.WIFPrefix) {
+		return nil, false, fmt.Errorf("%w: `%x` for `%s` WIF", ErrInvalidPrefix, prefix, p.Name)
+	}
+	compressed := false
+	if len(p.WIFKeyType) > 0 {
+		if !bytes.HasPrefix(payload, p.WIFKeyType) {
+			return nil, false, fmt.Errorf("%w: unknown key type", ErrInvalidPvtKey)
+		}
+		payload, compressed = payload[len(p.WIFKeyType):], true
+	} else if len(payload) == PvtKeyLength+1 && payload[PvtKeyLength] == compressedFlag {
+		payload, compressed = payload[:PvtKeyLength], true
+	}
+	if err := ValidatePvtKey(payload); err != nil {
+		return nil, false, err
+	}
+	return payload, compressed, nil
+}
+
+// DecodeWIF decodes wif with the parameters of every registered network and
+// returns the networks it's valid for. Its error is the one of the network
+// whose decoding went the furthest.
+func DecodeWIF(wif string) (*WIF, error) {
+	var decoded *WIF
+	err, errRank := ErrInvalidBase58, 0
+	for _, p := range RegParams {
+		if len(p.WIFPrefix) == 0 {
+			continue
+		}
+		pvtKey, compressed, pErr := p.DecodeWIF(wif)
+		if pErr == nil {
+			if decoded == nil {
+				decoded = &WIF{PvtKey: pvtKey, Compressed: compressed}
+			}
+			decoded.Networks = append(decoded.Networks, p)
+			continue
+		}
+		if rank := wifErrRank(pErr); rank > errRank {
+			err, errRank = pErr, rank
+		}
+	}
+	if decoded != nil {
+		return decoded, nil
+	}
+	if errors.Is(err, ErrInvalidPrefix) {
+		return nil, fmt.Errorf("%w: `%v`", ErrUnknownNetwork, wif)
+	}
+	return nil, err
+}
+
+// wifErrRank ranks the errors of DecodeWIF by how far the decoding went
+func wifErrRank(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidBase58):
+		return 0
+	case errors.Is(err, ErrInvalidChecksum):
+		return 1
+	case errors.Is(err, ErrInvalidPrefix):
+		return 2
+	}
+	return 3
+}
+
+// ValidatePvtKey verifies that pvtKey is a 32 bytes secp256k1 scalar in
+// [1, n-1]
+func ValidatePvtKey(pvtKey []byte) error {
+	if len(pvtKey) != PvtKeyLength {
+		return fmt.Errorf("%w: %d bytes", ErrInvalidPvtKey, len(pvtKey))
+	}
+	if err := bip32.ValidatePrivateKey(bip32.PvtKeyBytes(pvtKey)); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPvtKey, err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package qrcode
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// QuietZone is the light border, in modules, readers expect around a symbol
+const QuietZone = 4
+
+// Image renders the symbol with each module scale pixels wide,
+// surrounded by the QuietZone.
+func (q *QRCode) Image(scale int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+	palette := color.Palette{color.White, color.Black}
+	side := (q.Size + 2*QuietZone) * scale
+	img := image.NewPaletted(image.Rect(0, 0, side, side), palette)
+	for py := 0; py < side; py++ {
+		for px := 0; px < side; px++ {
+			if q.Dark(px/scale-QuietZone, py/scale-QuietZone) {
+				img.SetColorIndex(px, py, 1)
+			}
+		}
+	}
+	return img
+}
+
+// WritePNG writes the symbol as a PNG image, see Image
+func (q *QRCode) WritePNG(w io.Writer, scale int) error {
+	return png.Encode(w, q.Image(scale))
+}
+
+// PNG returns the symbol as PNG image bytes, see Image
+func (q *QRCode) PNG(scale int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := q.WritePNG(&buf, scale); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
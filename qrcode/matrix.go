@@ -0,0 +1,254 @@
+package qrcode
+
+// draw lays out the function patterns and the codewords, then applies the
+// mask with the lowest penalty.
+func (q *QRCode) draw(codewords []byte) {
+	q.modules = newGrid(q.Size)
+	reserved := newGrid(q.Size)
+	q.drawFunctionPatterns(reserved)
+	q.drawCodewords(codewords, reserved)
+
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask, reserved)
+		q.drawFormatBits(mask, reserved)
+		if penalty := q.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		// masking is an xor, applying it again reverts it
+		q.applyMask(mask, reserved)
+	}
+	q.Mask = best
+	q.applyMask(best, reserved)
+	q.drawFormatBits(best, reserved)
+}
+
+func newGrid(size int) [][]bool {
+	grid := make([][]bool, size)
+	for i := range grid {
+		grid[i] = make([]bool, size)
+	}
+	return grid
+}
+
+func (q *QRCode) set(x, y int, dark bool, reserved [][]bool) {
+	q.modules[y][x] = dark
+	reserved[y][x] = true
+}
+
+func (q *QRCode) drawFunctionPatterns(reserved [][]bool) {
+	// Timing patterns
+	for i := 0; i < q.Size; i++ {
+		q.set(6, i, i%2 == 0, reserved)
+		q.set(i, 6, i%2 == 0, reserved)
+	}
+
+	// Finder patterns with their separators
+	for _, c := range [][2]int{{3, 3}, {q.Size - 4, 3}, {3, q.Size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := c[0]+dx, c[1]+dy
+				if x < 0 || y < 0 || x >= q.Size || y >= q.Size {
+					continue
+				}
+				dist := max(abs(dx), abs(dy))
+				q.set(x, y, dist != 2 && dist != 4, reserved)
+			}
+		}
+	}
+
+	// Alignment patterns, except where they overlap the finder patterns
+	centers := alignmentCenters[q.Version-1]
+	last := len(centers) - 1
+	for i, cy := range centers {
+		for j, cx := range centers {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					q.set(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1, reserved)
+				}
+			}
+		}
+	}
+
+	// Reserve the format areas, the real bits are drawn with the mask
+	q.drawFormatBits(0, reserved)
+	q.drawVersionBits(reserved)
+}
+
+// drawFormatBits draws both copies of the 15 bit format information
+// and the dark module.
+func (q *QRCode) drawFormatBits(mask int, reserved [][]bool) {
+	data := uint32([]int{1, 0, 3, 2}[q.Level]<<3 | mask)
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool {
+		return bits>>uint(i)&1 == 1
+	}
+
+	for i := 0; i <= 5; i++ {
+		q.set(8, i, bit(i), reserved)
+	}
+	q.set(8, 7, bit(6), reserved)
+	q.set(8, 8, bit(7), reserved)
+	q.set(7, 8, bit(8), reserved)
+	for i := 9; i < 15; i++ {
+		q.set(14-i, 8, bit(i), reserved)
+	}
+
+	for i := 0; i < 8; i++ {
+		q.set(q.Size-1-i, 8, bit(i), reserved)
+	}
+	for i := 8; i < 15; i++ {
+		q.set(8, q.Size-15+i, bit(i), reserved)
+	}
+	q.set(8, q.Size-8, true, reserved)
+}
+
+// drawVersionBits draws both copies of the 18 bit version information
+// used by version 7 and above.
+func (q *QRCode) drawVersionBits(reserved [][]bool) {
+	if q.Version < 7 {
+		return
+	}
+	rem := uint32(q.Version)
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1f25
+	}
+	bits := uint32(q.Version)<<12 | rem
+	for i := 0; i < 18; i++ {
+		dark := bits>>uint(i)&1 == 1
+		a, b := q.Size-11+i%3, i/3
+		q.set(a, b, dark, reserved)
+		q.set(b, a, dark, reserved)
+	}
+}
+
+// drawCodewords places the codewords in the two module wide zigzag
+// going up and down from the bottom right corner.
+func (q *QRCode) drawCodewords(codewords []byte, reserved [][]bool) {
+	i := 0
+	for right := q.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < q.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = q.Size - 1 - vert
+				}
+				if reserved[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				q.modules[y][x] = codewords[i/8]>>uint(7-i%8)&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+func (q *QRCode) applyMask(mask int, reserved [][]bool) {
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			if reserved[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			q.modules[y][x] = q.modules[y][x] != invert
+		}
+	}
+}
+
+// penalty scores the symbol with the four rules of the specification,
+// a lower score reads more reliably.
+func (q *QRCode) penalty() int {
+	score := 0
+	dark := 0
+	finderLike := [][]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+	for pass := 0; pass < 2; pass++ {
+		at := func(i, j int) bool {
+			if pass == 0 {
+				return q.modules[i][j]
+			}
+			return q.modules[j][i]
+		}
+		for i := 0; i < q.Size; i++ {
+			run := 1
+			for j := 1; j <= q.Size; j++ {
+				if j < q.Size && at(i, j) == at(i, j-1) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += 3 + run - 5
+				}
+				run = 1
+			}
+			for j := 0; j+11 <= q.Size; j++ {
+				for _, pattern := range finderLike {
+					matches := true
+					for k, p := range pattern {
+						if at(i, j+k) != p {
+							matches = false
+							break
+						}
+					}
+					if matches {
+						score += 40
+					}
+				}
+			}
+		}
+	}
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x+1 < q.Size && y+1 < q.Size {
+				c := q.modules[y][x]
+				if q.modules[y][x+1] == c && q.modules[y+1][x] == c && q.modules[y+1][x+1] == c {
+					score += 3
+				}
+			}
+		}
+	}
+	total := q.Size * q.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return score + k*10
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,293 @@
+// Package qrcode is a small, dependency free QR Code encoder.
+//
+// It supports the numeric and byte modes, versions 1 to 40 and the four
+// error correction levels, which is all that's needed to display mnemonic
+// payloads such as SeedQR offline.
+//
+// Ref ISO/IEC 18004:2015
+package qrcode
+
+import (
+	"errors"
+)
+
+// ECLevel is the error correction level of a QR Code
+type ECLevel int
+
+const (
+	// Low recovers about 7% of the codewords
+	Low ECLevel = iota
+	// Medium recovers about 15% of the codewords
+	Medium
+	// Quartile recovers about 25% of the codewords
+	Quartile
+	// High recovers about 30% of the codewords
+	High
+)
+
+// Mode is the data encoding mode of a QR Code segment
+type Mode int
+
+const (
+	// Numeric mode packs three decimal digits into 10 bits
+	Numeric Mode = iota
+	// Byte mode stores raw 8 bit bytes
+	Byte
+)
+
+const (
+	MinVersion = 1
+	MaxVersion = 40
+)
+
+var (
+	ErrDataTooLong     = errors.New("data too long for a QR Code")
+	ErrInvalidECLevel  = errors.New("invalid error correction level")
+	ErrInvalidNumeric  = errors.New("numeric mode accepts only decimal digits")
+	ErrInvalidVersion  = errors.New("version must be in range 1 to 40")
+	ErrUnsupportedMode = errors.New("unsupported mode")
+)
+
+// QRCode is an encoded QR Code symbol
+type QRCode struct {
+	// Version of the symbol, between MinVersion and MaxVersion
+	Version int
+	// Level of error correction
+	Level ECLevel
+	// Mode used for the data
+	Mode Mode
+	// Mask pattern applied to the data modules, between 0 and 7
+	Mask int
+	// Size is the width and height in modules
+	Size    int
+	modules [][]bool
+}
+
+// Encode encodes data in the smallest version that fits it at the given level.
+// Numeric mode is used when data is made of decimal digits only.
+func Encode(data []byte, level ECLevel) (*QRCode, error) {
+	mode := Byte
+	if len(data) > 0 && isNumeric(data) {
+		mode = Numeric
+	}
+	return EncodeMode(data, mode, level)
+}
+
+// EncodeMode encodes data with the given mode in the smallest version
+// that fits it at the given level.
+func EncodeMode(data []byte, mode Mode, level ECLevel) (*QRCode, error) {
+	if level < Low || level > High {
+		return nil, ErrInvalidECLevel
+	}
+	switch mode {
+	case Numeric:
+		if !isNumeric(data) {
+			return nil, ErrInvalidNumeric
+		}
+	case Byte:
+	default:
+		return nil, ErrUnsupportedMode
+	}
+	for version := MinVersion; version <= MaxVersion; version++ {
+		capacity := blockLayouts[version-1][level].dataCodewords() * 8
+		if segmentBits(mode, len(data), version) <= capacity {
+			return encodeVersion(data, mode, level, version)
+		}
+	}
+	return nil, ErrDataTooLong
+}
+
+// Dark reports whether the module at column x and row y is dark.
+// Modules outside the symbol are light.
+func (q *QRCode) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= q.Size || y >= q.Size {
+		return false
+	}
+	return q.modules[y][x]
+}
+
+// Bitmap returns a copy of the modules indexed by row and then column
+func (q *QRCode) Bitmap() [][]bool {
+	bitmap := make([][]bool, q.Size)
+	for y := range bitmap {
+		bitmap[y] = append([]bool(nil), q.modules[y]...)
+	}
+	return bitmap
+}
+
+func encodeVersion(data []byte, mode Mode, level ECLevel, version int) (*QRCode, error) {
+	layout := blockLayouts[version-1][level]
+	var bits bitWriter
+	switch mode {
+	case Numeric:
+		bits.write(0b0001, 4)
+		bits.write(uint32(len(data)), charCountBits(mode, version))
+		for i := 0; i < len(data); i += 3 {
+			end := min(i+3, len(data))
+			var val uint32
+			for _, d := range data[i:end] {
+				val = val*10 + uint32(d-'0')
+			}
+			bits.write(val, (end-i)*3+1)
+		}
+	case Byte:
+		bits.write(0b0100, 4)
+		bits.write(uint32(len(data)), charCountBits(mode, version))
+		for _, b := range data {
+			bits.write(uint32(b), 8)
+		}
+	}
+
+	// Terminator, byte alignment and alternating pad codewords
+	capacity := layout.dataCodewords() * 8
+	bits.write(0, min(4, capacity-bits.len()))
+	bits.write(0, (8-bits.len()%8)%8)
+	for pad := uint32(0xec); bits.len() < capacity; pad ^= 0xec ^ 0x11 {
+		bits.write(pad, 8)
+	}
+
+	q := &QRCode{
+		Version: version,
+		Level:   level,
+		Mode:    mode,
+		Size:    version*4 + 17,
+	}
+	q.draw(interleave(bits.bytes, layout))
+	return q, nil
+}
+
+// interleave splits the data codewords into blocks, appends the
+// Reed-Solomon codewords of each block and interleaves the result.
+func interleave(data []byte, layout blockLayout) []byte {
+	numBlocks := layout.Group1Blocks + layout.Group2Blocks
+	blocks := make([][]byte, numBlocks)
+	ecBlocks := make([][]byte, numBlocks)
+	generator := rsGenerator(layout.ECCodewords)
+	offset := 0
+	for i := range blocks {
+		size := layout.Group1DataCodewords
+		if i >= layout.Group1Blocks {
+			size++
+		}
+		blocks[i] = data[offset : offset+size]
+		ecBlocks[i] = rsRemainder(blocks[i], generator)
+		offset += size
+	}
+	result := make([]byte, 0, len(data)+numBlocks*layout.ECCodewords)
+	for i := 0; i <= layout.Group1DataCodewords; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < layout.ECCodewords; i++ {
+		for _, ec := range ecBlocks {
+			result = append(result, ec[i])
+		}
+	}
+	return result
+}
+
+func charCountBits(mode Mode, version int) int {
+	switch {
+	case mode == Numeric && version <= 9:
+		return 10
+	case mode == Numeric && version <= 26:
+		return 12
+	case mode == Numeric:
+		return 14
+	case version <= 9:
+		return 8
+	default:
+		return 16
+	}
+}
+
+func segmentBits(mode Mode, length, version int) int {
+	bits := 4 + charCountBits(mode, version)
+	if mode == Numeric {
+		return bits + length/3*10 + []int{0, 4, 7}[length%3]
+	}
+	return bits + length*8
+}
+
+func isNumeric(data []byte) bool {
+	for _, b := range data {
+		if b < '0' || b > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+type bitWriter struct {
+	bytes []byte
+	n     int
+}
+
+func (w *bitWriter) len() int {
+	return w.n
+}
+
+// write appends the count least significant bits of val, most significant first
+func (w *bitWriter) write(val uint32, count int) {
+	for i := count - 1; i >= 0; i-- {
+		if w.n%8 == 0 {
+			w.bytes = append(w.bytes, 0)
+		}
+		if val>>uint(i)&1 == 1 {
+			w.bytes[w.n/8] |= 0x80 >> uint(w.n%8)
+		}
+		w.n++
+	}
+}
+
+// Reed-Solomon over GF(256) with the primitive polynomial
+// x^8 + x^4 + x^3 + x^2 + 1
+func gfMul(a, b byte) byte {
+	var p byte
+	for b > 0 {
+		if b&1 == 1 {
+			p ^= a
+		}
+		carry := a & 0x80
+		a <<= 1
+		if carry != 0 {
+			a ^= 0x1d
+		}
+		b >>= 1
+	}
+	return p
+}
+
+// rsGenerator returns the coefficients, highest degree first and without
+// the leading one, of the product of (x - 2^i) for i in [0, degree)
+func rsGenerator(degree int) []byte {
+	gen := make([]byte, degree)
+	gen[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := 0; j < degree; j++ {
+			gen[j] = gfMul(gen[j], root)
+			if j+1 < degree {
+				gen[j] ^= gen[j+1]
+			}
+		}
+		root = gfMul(root, 2)
+	}
+	return gen
+}
+
+func rsRemainder(data, generator []byte) []byte {
+	rem := make([]byte, len(generator))
+	for _, b := range data {
+		factor := b ^ rem[0]
+		copy(rem, rem[1:])
+		rem[len(rem)-1] = 0
+		for i, g := range generator {
+			rem[i] ^= gfMul(g, factor)
+		}
+	}
+	return rem
+}
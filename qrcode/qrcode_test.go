@@ -0,0 +1,97 @@
+package qrcode
+
+import (
+	"bytes"
+	"encoding/hex"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReedSolomon uses the 1-M "01234567" example of ISO/IEC 18004 Annex I
+func TestReedSolomon(t *testing.T) {
+	var bits bitWriter
+	bits.write(0b0001, 4)
+	bits.write(8, charCountBits(Numeric, 1))
+	bits.write(12, 10)
+	bits.write(345, 10)
+	bits.write(67, 7)
+	assert.Equal(t, 41, bits.len())
+
+	assert.Equal(t, "10200c5661", hex.EncodeToString(bits.bytes[:5]))
+
+	data, _ := hex.DecodeString("10200c566180ec11ec11ec11ec11ec11")
+	layout := blockLayouts[0][Medium]
+	assert.Equal(t, layout.dataCodewords(), len(data))
+	ec := rsRemainder(data, rsGenerator(layout.ECCodewords))
+	assert.Equal(t, "a524d4c1ed36c7872c55", hex.EncodeToString(ec))
+}
+
+func TestEncodeVersion(t *testing.T) {
+	for _, v := range []struct {
+		data    string
+		level   ECLevel
+		mode    Mode
+		version int
+	}{
+		{"01234567", Medium, Numeric, 1},
+		{strings.Repeat("0", 48), Low, Numeric, 2},
+		{strings.Repeat("0", 96), Low, Numeric, 3},
+		{strings.Repeat("a", 16), Low, Byte, 1},
+		{strings.Repeat("a", 32), Low, Byte, 2},
+		{strings.Repeat("a", 2953), Low, Byte, 40},
+	} {
+		q, err := Encode([]byte(v.data), v.level)
+		assert.NoError(t, err)
+		assert.Equal(t, v.version, q.Version)
+		assert.Equal(t, v.mode, q.Mode)
+		assert.Equal(t, v.version*4+17, q.Size)
+	}
+
+	_, err := Encode([]byte(strings.Repeat("a", 2954)), Low)
+	assert.Equal(t, ErrDataTooLong, err)
+	_, err = EncodeMode([]byte("12a"), Numeric, Low)
+	assert.Equal(t, ErrInvalidNumeric, err)
+	_, err = Encode([]byte("1"), ECLevel(4))
+	assert.Equal(t, ErrInvalidECLevel, err)
+}
+
+func TestFunctionPatterns(t *testing.T) {
+	q, err := Encode([]byte(strings.Repeat("b", 200)), Quartile)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, q.Version)
+	for i := 0; i < 7; i++ {
+		// finder pattern outer rings
+		assert.True(t, q.Dark(i, 0))
+		assert.True(t, q.Dark(q.Size-1-i, 6))
+		assert.True(t, q.Dark(0, q.Size-1-i))
+		// separators
+		assert.False(t, q.Dark(i, 7))
+		assert.False(t, q.Dark(7, i))
+	}
+	for i := 8; i < q.Size-8; i++ {
+		assert.Equal(t, i%2 == 0, q.Dark(i, 6))
+		assert.Equal(t, i%2 == 0, q.Dark(6, i))
+	}
+	assert.True(t, q.Dark(8, q.Size-8))
+	assert.False(t, q.Dark(-1, 0))
+	assert.False(t, q.Dark(0, q.Size))
+}
+
+func TestWritePNG(t *testing.T) {
+	q, err := Encode([]byte("073318950739065415961602009907670428187212261116"), Low)
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	assert.NoError(t, q.WritePNG(&buf, 3))
+	img, err := png.Decode(&buf)
+	assert.NoError(t, err)
+	side := (q.Size + 2*QuietZone) * 3
+	assert.Equal(t, side, img.Bounds().Dx())
+	assert.Equal(t, side, img.Bounds().Dy())
+	r, _, _, _ := img.At(QuietZone*3, QuietZone*3).RGBA()
+	assert.Equal(t, uint32(0), r)
+	r, _, _, _ = img.At(0, 0).RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+}
@@ -0,0 +1,121 @@
+// Package ripple implements the XRP Ledger X-addresses, the classic address
+// account id packed with an optional destination tag and the network, and
+// the conversion from and to classic r… addresses, see netparams.Ripple.
+//
+// The X-address spec can be found at
+// https://github.com/XRPLF/XRPL-Standards/discussions/37
+package ripple
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/mearaj/bips/netparams"
+)
+
+// AccountIDLength is the length of an account id in bytes
+const AccountIDLength = 20
+
+// xAddressLength is the length of the decoded X-address, checksum excluded
+const xAddressLength = 2 + AccountIDLength + 1 + 8
+
+// Version prefixes of X-addresses
+var (
+	MainnetPrefix = []byte{0x05, 0x44}
+	TestnetPrefix = []byte{0x04, 0x93}
+)
+
+var (
+	ErrInvalidLength  = errors.New("invalid address length")
+	ErrInvalidPrefix  = errors.New("invalid X-address prefix")
+	ErrInvalidFlags   = errors.New("invalid X-address tag flags")
+	ErrInvalidTag     = errors.New("invalid X-address tag")
+	ErrInvalidClassic = errors.New("invalid classic address")
+)
+
+// XAddress is an account and an optional destination tag on a network
+type XAddress struct {
+	AccountID [AccountIDLength]byte
+	// Tag is the destination tag, meaningful only if HasTag
+	Tag     uint32
+	HasTag  bool
+	Testnet bool
+}
+
+// NewXAddress returns the X-address of the classic address, with the
+// destination tag if hasTag
+func NewXAddress(classic string, tag uint32, hasTag, testnet bool) (XAddress, error) {
+	x := XAddress{Tag: tag, HasTag: hasTag, Testnet: testnet}
+	prefix, accountID, err := netparams.Ripple.DecodeBase58Check(classic, 1)
+	if err != nil {
+		return x, fmt.Errorf("%w: %v", ErrInvalidClassic, err)
+	}
+	if prefix[0] != netparams.Ripple.PubKeyHashPrefix[0] || len(accountID) != AccountIDLength {
+		return x, fmt.Errorf("%w: `%v`", ErrInvalidClassic, classic)
+	}
+	copy(x.AccountID[:], accountID)
+	return x, nil
+}
+
+// ParseXAddress parses an X… mainnet or T… testnet address
+func ParseXAddress(s string) (XAddress, error) {
+	var x XAddress
+	prefix, data, err := netparams.Ripple.DecodeBase58Check(s, 2)
+	if err != nil {
+		return x, err
+	}
+	if len(prefix)+len(data) != xAddressLength {
+		return x, fmt.Errorf("%w: %d bytes", ErrInvalidLength, len(prefix)+len(data))
+	}
+	switch string(prefix) {
+	case string(MainnetPrefix):
+	case string(TestnetPrefix):
+		x.Testnet = true
+	default:
+		return x, fmt.Errorf("%w: `%x`", ErrInvalidPrefix, prefix)
+	}
+	copy(x.AccountID[:], data)
+	flags, tag := data[AccountIDLength], data[AccountIDLength+1:]
+	// the upper 4 bytes are reserved for 64 bits tags, which aren't in use
+	if binary.LittleEndian.Uint32(tag[4:]) != 0 {
+		return x, ErrInvalidTag
+	}
+	switch flags {
+	case 0:
+		if binary.LittleEndian.Uint32(tag) != 0 {
+			return x, ErrInvalidTag
+		}
+	case 1:
+		x.HasTag = true
+		x.Tag = binary.LittleEndian.Uint32(tag)
+	default:
+		return x, fmt.Errorf("%w: %d", ErrInvalidFlags, flags)
+	}
+	return x, nil
+}
+
+// String returns the X-address, ex X7AcgcsBL6XDcUb289X4mJ8djcdyKaB5hJDWMArnXr61cqZ
+func (x XAddress) String() string {
+	prefix := MainnetPrefix
+	if x.Testnet {
+		prefix = TestnetPrefix
+	}
+	data := make([]byte, 0, xAddressLength-len(prefix))
+	data = append(data, x.AccountID[:]...)
+	if x.HasTag {
+		data = append(data, 1)
+		data = binary.LittleEndian.AppendUint32(data, x.Tag)
+	} else {
+		data = append(data, 0, 0, 0, 0, 0)
+	}
+	data = append(data, 0, 0, 0, 0)
+	s, _ := netparams.Ripple.EncodeBase58Check(prefix, data)
+	return s
+}
+
+// ClassicAddress returns the r… address of the account
+func (x XAddress) ClassicAddress() string {
+	s, _ := netparams.Ripple.AddrFromPubKeyHash(x.AccountID[:])
+	return s
+}
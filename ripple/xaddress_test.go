@@ -0,0 +1,56 @@
+package ripple
+
+import (
+	"testing"
+
+	"github.com/mearaj/bips/netparams"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Ref https://github.com/XRPLF/xrpl.js/blob/main/packages/ripple-address-codec/src/xrp-codec.test.ts
+func TestXAddress(t *testing.T) {
+	vectors := []struct {
+		classic  string
+		tag      uint32
+		hasTag   bool
+		testnet  bool
+		xAddress string
+	}{
+		{"r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", 0, false, false, "X7AcgcsBL6XDcUb289X4mJ8djcdyKaB5hJDWMArnXr61cqZ"},
+		{"r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", 1, true, false, "X7AcgcsBL6XDcUb289X4mJ8djcdyKaGZMhc9YTE92ehJ2Fu"},
+		{"r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", 0, false, true, "T719a5UwUCnEs54UsxG9CJYYDhwmFCqkr7wxCcNcfZ6p5GZ"},
+	}
+	for _, v := range vectors {
+		x, err := NewXAddress(v.classic, v.tag, v.hasTag, v.testnet)
+		require.NoError(t, err)
+		assert.Equal(t, v.xAddress, x.String())
+		parsed, err := ParseXAddress(v.xAddress)
+		require.NoError(t, err)
+		assert.Equal(t, x, parsed)
+		assert.Equal(t, v.classic, parsed.ClassicAddress())
+	}
+}
+
+func TestXAddressErrors(t *testing.T) {
+	_, err := NewXAddress("1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", 0, false, false)
+	assert.ErrorIs(t, err, ErrInvalidClassic)
+	_, err = ParseXAddress("r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59")
+	assert.ErrorIs(t, err, ErrInvalidLength)
+
+	x, err := NewXAddress("r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", 0, false, false)
+	require.NoError(t, err)
+	data := append(x.AccountID[:], 2, 0, 0, 0, 0, 0, 0, 0, 0)
+	s, err := netparams.Ripple.EncodeBase58Check(MainnetPrefix, data)
+	require.NoError(t, err)
+	_, err = ParseXAddress(s)
+	assert.ErrorIs(t, err, ErrInvalidFlags)
+	data = append(x.AccountID[:], 0, 1, 0, 0, 0, 0, 0, 0, 0)
+	s, _ = netparams.Ripple.EncodeBase58Check(MainnetPrefix, data)
+	_, err = ParseXAddress(s)
+	assert.ErrorIs(t, err, ErrInvalidTag)
+	s, _ = netparams.Ripple.EncodeBase58Check([]byte{0x05, 0x45}, data)
+	_, err = ParseXAddress(s)
+	assert.ErrorIs(t, err, ErrInvalidPrefix)
+}
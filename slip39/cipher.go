@@ -0,0 +1,53 @@
+package slip39
+
+import (
+	"crypto/sha256"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// baseIterationCount is the PBKDF2 iteration count for exponent 0,
+	// split evenly between the Feistel rounds
+	baseIterationCount = 10000
+	roundCount         = 4
+)
+
+// encrypt the master secret with the passphrase using the four round
+// Feistel network of slip39
+func encrypt(masterSecret, passphrase []byte, iterationExponent byte, identifier uint16, extendable bool) []byte {
+	return feistel(masterSecret, passphrase, iterationExponent, identifier, extendable, false)
+}
+
+// decrypt is the inverse of encrypt, the rounds are applied in reverse order
+func decrypt(encryptedSecret, passphrase []byte, iterationExponent byte, identifier uint16, extendable bool) []byte {
+	return feistel(encryptedSecret, passphrase, iterationExponent, identifier, extendable, true)
+}
+
+func feistel(data, passphrase []byte, iterationExponent byte, identifier uint16, extendable bool, reverse bool) []byte {
+	half := len(data) / 2
+	l := append([]byte(nil), data[:half]...)
+	r := append([]byte(nil), data[half:]...)
+	salt := saltPrefix(identifier, extendable)
+	iterations := (baseIterationCount << iterationExponent) / roundCount
+	for i := 0; i < roundCount; i++ {
+		round := byte(i)
+		if reverse {
+			round = byte(roundCount - 1 - i)
+		}
+		password := append([]byte{round}, passphrase...)
+		f := pbkdf2.Key(password, append(append([]byte(nil), salt...), r...), iterations, len(r), sha256.New)
+		for j := range l {
+			l[j] ^= f[j]
+		}
+		l, r = r, l
+	}
+	return append(r, l...)
+}
+
+func saltPrefix(identifier uint16, extendable bool) []byte {
+	if extendable {
+		return nil
+	}
+	return append([]byte(customizationNonExtendable), byte(identifier>>8), byte(identifier))
+}
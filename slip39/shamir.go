@@ -0,0 +1,130 @@
+package slip39
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+)
+
+const (
+	// secretIndex is the x coordinate of the shared secret
+	secretIndex = 255
+	// digestIndex is the x coordinate of the share holding the digest
+	digestIndex = 254
+	digestBytes = 4
+)
+
+// GF(256) with the Rijndael polynomial x^8 + x^4 + x^3 + x + 1
+// and 3 as generator
+var (
+	gfExp [255]byte
+	gfLog [256]byte
+)
+
+func init() {
+	poly := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(poly)
+		gfLog[poly] = byte(i)
+		// multiply poly by the generator x + 1
+		poly = poly<<1 ^ poly
+		if poly&0x100 != 0 {
+			poly ^= 0x11b
+		}
+	}
+}
+
+type point struct {
+	x     byte
+	value []byte
+}
+
+// interpolate returns the value at x of the polynomial going through points,
+// all x coordinates have to be distinct.
+func interpolate(points []point, x byte) []byte {
+	for _, p := range points {
+		if p.x == x {
+			return append([]byte(nil), p.value...)
+		}
+	}
+	// Lagrange basis product of (x - x_k) for all k, in the log domain,
+	// addition and subtraction being xor in GF(256)
+	logProd := 0
+	for _, p := range points {
+		logProd += int(gfLog[p.x^x])
+	}
+	result := make([]byte, len(points[0].value))
+	for i, p := range points {
+		logBasis := logProd - int(gfLog[p.x^x])
+		for j, o := range points {
+			if i != j {
+				logBasis -= int(gfLog[p.x^o.x])
+			}
+		}
+		logBasis = (logBasis%255 + 255) % 255
+		for k, v := range p.value {
+			if v != 0 {
+				result[k] ^= gfExp[(int(gfLog[v])+logBasis)%255]
+			}
+		}
+	}
+	return result
+}
+
+func createDigest(randomData, sharedSecret []byte) []byte {
+	mac := hmac.New(sha256.New, randomData)
+	mac.Write(sharedSecret)
+	return mac.Sum(nil)[:digestBytes]
+}
+
+// splitSecret shares secret so that any threshold of the count points
+// can recover it
+func splitSecret(threshold, count byte, secret []byte) ([]point, error) {
+	if threshold < 1 || threshold > count || count > MaxShareCount {
+		return nil, ErrInvalidThreshold
+	}
+	points := make([]point, 0, count)
+	if threshold == 1 {
+		for i := byte(0); i < count; i++ {
+			points = append(points, point{x: i, value: append([]byte(nil), secret...)})
+		}
+		return points, nil
+	}
+
+	randomShares := threshold - 2
+	for i := byte(0); i < randomShares; i++ {
+		value := make([]byte, len(secret))
+		if _, err := rand.Read(value); err != nil {
+			return nil, err
+		}
+		points = append(points, point{x: i, value: value})
+	}
+	randomPart := make([]byte, len(secret)-digestBytes)
+	if _, err := rand.Read(randomPart); err != nil {
+		return nil, err
+	}
+	digest := append(createDigest(randomPart, secret), randomPart...)
+	base := append(append([]point(nil), points...),
+		point{x: digestIndex, value: digest},
+		point{x: secretIndex, value: secret},
+	)
+	for i := randomShares; i < count; i++ {
+		points = append(points, point{x: i, value: interpolate(base, i)})
+	}
+	return points, nil
+}
+
+// recoverSecret recovers the secret from threshold points and verifies its digest
+func recoverSecret(threshold byte, points []point) ([]byte, error) {
+	if threshold == 1 {
+		return points[0].value, nil
+	}
+	secret := interpolate(points, secretIndex)
+	digestShare := interpolate(points, digestIndex)
+	digest, randomPart := digestShare[:digestBytes], digestShare[digestBytes:]
+	if subtle.ConstantTimeCompare(digest, createDigest(randomPart, secret)) != 1 {
+		return nil, ErrInvalidDigest
+	}
+	return secret, nil
+}
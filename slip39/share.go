@@ -0,0 +1,178 @@
+package slip39
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	radixBits = 10
+	// idBits is the length of the random identifier
+	idBits = 15
+	// iterationExpBits is the length of the iteration exponent
+	iterationExpBits = 4
+	// checksumWords is the number of words of the RS1024 checksum
+	checksumWords = 3
+	// metadataWords is the number of words before the share value:
+	// id, extendable flag, iteration exponent, group index, group threshold,
+	// group count, member index and member threshold
+	metadataWords = 4
+	// MinMnemonicWords is the length of a share of a 128 bit master secret
+	MinMnemonicWords = metadataWords + checksumWords + (MinSecretBytes*8+radixBits-1)/radixBits
+
+	customizationNonExtendable = "shamir"
+	customizationExtendable    = "shamir_extendable"
+)
+
+// Share is a single slip39 mnemonic share
+type Share struct {
+	// Identifier is the random 15 bit identifier common to all shares of a secret
+	Identifier uint16
+	// Extendable tells whether more shares can be added with the same identifier,
+	// it also removes the identifier from the encryption salt
+	Extendable bool
+	// IterationExponent sets the PBKDF2 iterations to 10000 << IterationExponent
+	IterationExponent byte
+	GroupIndex        byte
+	GroupThreshold    byte
+	GroupCount        byte
+	MemberIndex       byte
+	MemberThreshold   byte
+	// Value is the share of the group secret
+	Value []byte
+}
+
+// ParseShare decodes and validates a mnemonic share
+func ParseShare(mnemonic string) (Share, error) {
+	var share Share
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) < MinMnemonicWords {
+		return share, ErrInvalidMnemonicLength
+	}
+	data := make([]int, len(words))
+	for i, w := range words {
+		idx, ok := wordMap[w]
+		if !ok {
+			return share, fmt.Errorf("%w: `%v`", ErrInvalidWord, w)
+		}
+		data[i] = idx
+	}
+
+	share.Extendable = data[1]>>iterationExpBits&1 == 1
+	if rs1024Polymod(customization(share.Extendable), data) != 1 {
+		return share, ErrInvalidChecksum
+	}
+
+	share.Identifier = uint16(data[0]<<5 | data[1]>>5)
+	share.IterationExponent = byte(data[1] & 0xf)
+	share.GroupIndex = byte(data[2] >> 6)
+	share.GroupThreshold = byte(data[2]>>2&0xf) + 1
+	share.GroupCount = byte((data[2]&3)<<2|data[3]>>8) + 1
+	share.MemberIndex = byte(data[3] >> 4 & 0xf)
+	share.MemberThreshold = byte(data[3]&0xf) + 1
+	if share.GroupThreshold > share.GroupCount {
+		return share, ErrInvalidGroupThreshold
+	}
+	if share.GroupIndex >= share.GroupCount {
+		return share, ErrInvalidGroupIndex
+	}
+
+	valueWords := data[metadataWords : len(data)-checksumWords]
+	paddingBits := len(valueWords) * radixBits % 16
+	if paddingBits > 8 {
+		return share, ErrInvalidMnemonicLength
+	}
+	value := new(big.Int)
+	for _, w := range valueWords {
+		value.Lsh(value, radixBits)
+		value.Or(value, big.NewInt(int64(w)))
+	}
+	valueBytes := (len(valueWords)*radixBits - paddingBits) / 8
+	if value.BitLen() > valueBytes*8 {
+		return share, ErrInvalidPadding
+	}
+	share.Value = value.FillBytes(make([]byte, valueBytes))
+	return share, nil
+}
+
+// Mnemonic encodes the share with its RS1024 checksum
+func (s Share) Mnemonic() string {
+	var ext int
+	if s.Extendable {
+		ext = 1
+	}
+	idExp := int(s.Identifier)<<(iterationExpBits+1) | ext<<iterationExpBits | int(s.IterationExponent)
+	data := []int{
+		idExp >> radixBits,
+		idExp & 0x3ff,
+		int(s.GroupIndex)<<6 | int(s.GroupThreshold-1)<<2 | int(s.GroupCount-1)>>2,
+		int(s.GroupCount-1)&3<<8 | int(s.MemberIndex)<<4 | int(s.MemberThreshold-1),
+	}
+
+	valueWords := (len(s.Value)*8 + radixBits - 1) / radixBits
+	value := new(big.Int).SetBytes(s.Value)
+	for i := valueWords - 1; i >= 0; i-- {
+		word := new(big.Int).Rsh(value, uint(i*radixBits))
+		data = append(data, int(word.Int64()&0x3ff))
+	}
+	data = append(data, rs1024Checksum(customization(s.Extendable), data)...)
+
+	words := make([]string, len(data))
+	for i, idx := range data {
+		words[i] = WordList[idx]
+	}
+	return strings.Join(words, " ")
+}
+
+// isCompatible tells whether both shares can belong to the same secret
+func (s Share) isCompatible(o Share) bool {
+	return s.Identifier == o.Identifier &&
+		s.Extendable == o.Extendable &&
+		s.IterationExponent == o.IterationExponent &&
+		s.GroupThreshold == o.GroupThreshold &&
+		s.GroupCount == o.GroupCount &&
+		len(s.Value) == len(o.Value)
+}
+
+func customization(extendable bool) string {
+	if extendable {
+		return customizationExtendable
+	}
+	return customizationNonExtendable
+}
+
+var rs1024Generator = [10]int{
+	0xe0e040, 0x1c1c080, 0x3838100, 0x7070200, 0xe0e0009,
+	0x1c0c2412, 0x38086c24, 0x3090fc48, 0x21b1f890, 0x3f3f120,
+}
+
+// rs1024Polymod is the Reed-Solomon code over GF(1024) used for the checksum
+func rs1024Polymod(customization string, data []int) int {
+	values := make([]int, 0, len(customization)+len(data))
+	for _, c := range []byte(customization) {
+		values = append(values, int(c))
+	}
+	values = append(values, data...)
+	chk := 1
+	for _, v := range values {
+		b := chk >> 20
+		chk = (chk&0xfffff)<<10 ^ v
+		for i, g := range rs1024Generator {
+			if b>>uint(i)&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	return chk
+}
+
+func rs1024Checksum(customization string, data []int) []int {
+	values := append(append([]int{}, data...), make([]int, checksumWords)...)
+	polymod := rs1024Polymod(customization, values) ^ 1
+	checksum := make([]int, checksumWords)
+	for i := range checksum {
+		checksum[i] = polymod >> uint(radixBits*(checksumWords-1-i)) & 0x3ff
+	}
+	return checksum
+}
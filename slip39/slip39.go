@@ -0,0 +1,197 @@
+// Package slip39 is the Golang implementation of SLIP-0039,
+// Shamir's Secret-Sharing for Mnemonic Codes.
+//
+// The master secret is encrypted with a passphrase, split into group shares
+// and every group share is split again into member shares. The recovered
+// master secret is meant to be used as the seed of bip32.NewMasterKey.
+//
+// The official SLIP-0039 spec can be found at
+// https://github.com/satoshilabs/slips/blob/master/slip-0039.md
+package slip39
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	// MinSecretBytes is the length of the shortest master secret
+	MinSecretBytes = 16
+	// MaxShareCount is the maximum number of groups and of members per group
+	MaxShareCount = 16
+	// MaxIterationExponent is the largest iteration exponent of a share
+	MaxIterationExponent = 1<<iterationExpBits - 1
+)
+
+var (
+	ErrInvalidMnemonicLength  = errors.New("invalid mnemonic length")
+	ErrInvalidWord            = errors.New("word is not in the slip39 word list")
+	ErrInvalidChecksum        = errors.New("invalid mnemonic checksum")
+	ErrInvalidPadding         = errors.New("invalid mnemonic padding")
+	ErrInvalidSecretLength    = errors.New("master secret must be at least 16 bytes and of even length")
+	ErrInvalidThreshold       = errors.New("threshold must be between 1 and the share count, share count at most 16")
+	ErrInvalidGroupThreshold  = errors.New("group threshold cannot be greater than group count")
+	ErrInvalidGroupIndex      = errors.New("group index must be less than the group count")
+	ErrInvalidMemberGroup     = errors.New("member threshold 1 requires a single member share")
+	ErrInvalidIterationExp    = errors.New("iteration exponent is too large")
+	ErrInvalidDigest          = errors.New("invalid digest of the shared secret")
+	ErrNoShares               = errors.New("no shares provided")
+	ErrMismatchingShares      = errors.New("shares don't belong to the same secret")
+	ErrDuplicateMemberIndex   = errors.New("duplicate member index")
+	ErrInsufficientGroups     = errors.New("insufficient number of groups")
+	ErrInsufficientShares     = errors.New("insufficient number of member shares")
+	ErrMismatchingMemberGroup = errors.New("member threshold differs within a group")
+)
+
+// MemberGroup is the member threshold and member count of a group
+type MemberGroup struct {
+	MemberThreshold byte
+	MemberCount     byte
+}
+
+// GenerateMnemonics splits the master secret into mnemonic shares.
+// Any groupThreshold groups, each with MemberThreshold of its shares,
+// recover the master secret. The result is indexed by group and member.
+func GenerateMnemonics(
+	groupThreshold byte,
+	groups []MemberGroup,
+	masterSecret, passphrase []byte,
+	extendable bool,
+	iterationExponent byte,
+) ([][]string, error) {
+	shares, err := GenerateShares(groupThreshold, groups, masterSecret, passphrase, extendable, iterationExponent)
+	if err != nil {
+		return nil, err
+	}
+	mnemonics := make([][]string, len(shares))
+	for i, group := range shares {
+		mnemonics[i] = make([]string, len(group))
+		for j, share := range group {
+			mnemonics[i][j] = share.Mnemonic()
+		}
+	}
+	return mnemonics, nil
+}
+
+// GenerateShares is GenerateMnemonics returning the decoded shares
+func GenerateShares(
+	groupThreshold byte,
+	groups []MemberGroup,
+	masterSecret, passphrase []byte,
+	extendable bool,
+	iterationExponent byte,
+) ([][]Share, error) {
+	if len(masterSecret) < MinSecretBytes || len(masterSecret)%2 != 0 {
+		return nil, ErrInvalidSecretLength
+	}
+	if int(groupThreshold) > len(groups) {
+		return nil, ErrInvalidGroupThreshold
+	}
+	if iterationExponent > MaxIterationExponent {
+		return nil, ErrInvalidIterationExp
+	}
+	for _, g := range groups {
+		if g.MemberThreshold == 1 && g.MemberCount > 1 {
+			return nil, ErrInvalidMemberGroup
+		}
+	}
+	var idBytes [2]byte
+	if _, err := rand.Read(idBytes[:]); err != nil {
+		return nil, err
+	}
+	identifier := binary.BigEndian.Uint16(idBytes[:]) & (1<<idBits - 1)
+
+	encrypted := encrypt(masterSecret, passphrase, iterationExponent, identifier, extendable)
+	groupPoints, err := splitSecret(groupThreshold, byte(len(groups)), encrypted)
+	if err != nil {
+		return nil, err
+	}
+	shares := make([][]Share, len(groups))
+	for i, groupPoint := range groupPoints {
+		memberPoints, err := splitSecret(groups[i].MemberThreshold, groups[i].MemberCount, groupPoint.value)
+		if err != nil {
+			return nil, err
+		}
+		for _, memberPoint := range memberPoints {
+			shares[i] = append(shares[i], Share{
+				Identifier:        identifier,
+				Extendable:        extendable,
+				IterationExponent: iterationExponent,
+				GroupIndex:        groupPoint.x,
+				GroupThreshold:    groupThreshold,
+				GroupCount:        byte(len(groups)),
+				MemberIndex:       memberPoint.x,
+				MemberThreshold:   groups[i].MemberThreshold,
+				Value:             memberPoint.value,
+			})
+		}
+	}
+	return shares, nil
+}
+
+// CombineMnemonics recovers the master secret from mnemonic shares
+func CombineMnemonics(mnemonics []string, passphrase []byte) ([]byte, error) {
+	shares := make([]Share, len(mnemonics))
+	for i, m := range mnemonics {
+		share, err := ParseShare(m)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i+1, err)
+		}
+		shares[i] = share
+	}
+	return CombineShares(shares, passphrase)
+}
+
+// CombineShares recovers the master secret from decoded shares
+func CombineShares(shares []Share, passphrase []byte) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, ErrNoShares
+	}
+	first := shares[0]
+	groups := map[byte][]Share{}
+	for _, s := range shares {
+		if !first.isCompatible(s) {
+			return nil, ErrMismatchingShares
+		}
+		for _, o := range groups[s.GroupIndex] {
+			if o.MemberIndex == s.MemberIndex {
+				return nil, ErrDuplicateMemberIndex
+			}
+			if o.MemberThreshold != s.MemberThreshold {
+				return nil, ErrMismatchingMemberGroup
+			}
+		}
+		groups[s.GroupIndex] = append(groups[s.GroupIndex], s)
+	}
+	// Incomplete groups are ignored, only group threshold complete groups
+	// with member threshold shares each are used
+	groupPoints := make([]point, 0, first.GroupThreshold)
+	for groupIndex, members := range groups {
+		threshold := members[0].MemberThreshold
+		if len(members) < int(threshold) || len(groupPoints) == int(first.GroupThreshold) {
+			continue
+		}
+		memberPoints := make([]point, threshold)
+		for i, m := range members[:threshold] {
+			memberPoints[i] = point{x: m.MemberIndex, value: m.Value}
+		}
+		groupSecret, err := recoverSecret(threshold, memberPoints)
+		if err != nil {
+			return nil, err
+		}
+		groupPoints = append(groupPoints, point{x: groupIndex, value: groupSecret})
+	}
+	if len(groupPoints) < int(first.GroupThreshold) {
+		if len(groups) < int(first.GroupThreshold) {
+			return nil, ErrInsufficientGroups
+		}
+		return nil, ErrInsufficientShares
+	}
+	encrypted, err := recoverSecret(first.GroupThreshold, groupPoints)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(encrypted, passphrase, first.IterationExponent, first.Identifier, first.Extendable), nil
+}
@@ -0,0 +1,193 @@
+package slip39
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mearaj/bips/bip32"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type vector struct {
+	description  string
+	mnemonics    []string
+	masterSecret string
+	xprv         string
+}
+
+// UnmarshalJSON decodes a vector of the official format
+// [description, mnemonics, master secret, xprv]
+func (v *vector) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &[4]interface{}{&v.description, &v.mnemonics, &v.masterSecret, &v.xprv})
+}
+
+// testVectors loads testdata/vectors.json, an unmodified copy of
+// https://github.com/trezor/python-shamir-mnemonic/blob/master/vectors.json
+func testVectors(t *testing.T) []vector {
+	data, err := os.ReadFile("testdata/vectors.json")
+	require.NoError(t, err)
+	var vectors []vector
+	require.NoError(t, json.Unmarshal(data, &vectors))
+	require.NotEmpty(t, vectors)
+	return vectors
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range testVectors(t) {
+		secret, err := CombineMnemonics(v.mnemonics, []byte("TREZOR"))
+		if v.masterSecret == "" {
+			assert.Error(t, err, v.description)
+			continue
+		}
+		assert.NoError(t, err, v.description)
+		assert.Equal(t, v.masterSecret, hex.EncodeToString(secret), v.description)
+		if v.xprv != "" {
+			key, err := bip32.NewMasterKey(secret)
+			assert.NoError(t, err, v.description)
+			assert.Equal(t, v.xprv, key.String(), v.description)
+		}
+		for _, m := range v.mnemonics {
+			share, err := ParseShare(m)
+			assert.NoError(t, err, v.description)
+			assert.Equal(t, m, share.Mnemonic(), v.description)
+		}
+	}
+}
+
+// TestInvalidShares alters the shares of a valid 2-of-3 sharing into the
+// invalid cases of the official vectors
+func TestInvalidShares(t *testing.T) {
+	mnemonics := []string{
+		"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed",
+		"shadow pistol academic acid actress prayer class unknown daughter sweater depict flip twice unkind craft early superior advocate guest smoking",
+	}
+	parse := func() (Share, Share) {
+		a, err := ParseShare(mnemonics[0])
+		require.NoError(t, err)
+		b, err := ParseShare(mnemonics[1])
+		require.NoError(t, err)
+		return a, b
+	}
+	combine := func(a, b Share) error {
+		_, err := CombineMnemonics([]string{a.Mnemonic(), b.Mnemonic()}, []byte("TREZOR"))
+		return err
+	}
+
+	a, b := parse()
+	b.Identifier ^= 1
+	assert.ErrorIs(t, combine(a, b), ErrMismatchingShares, "different identifiers")
+
+	a, b = parse()
+	b.IterationExponent++
+	assert.ErrorIs(t, combine(a, b), ErrMismatchingShares, "different iteration exponents")
+
+	a, b = parse()
+	b.GroupCount = 2
+	assert.ErrorIs(t, combine(a, b), ErrMismatchingShares, "mismatching group counts")
+
+	a, b = parse()
+	a.GroupCount, b.GroupCount, b.GroupThreshold = 2, 2, 2
+	assert.ErrorIs(t, combine(a, b), ErrMismatchingShares, "mismatching group thresholds")
+
+	a, b = parse()
+	b.MemberIndex = a.MemberIndex
+	assert.ErrorIs(t, combine(a, b), ErrDuplicateMemberIndex, "duplicate member indices")
+
+	a, b = parse()
+	b.MemberThreshold = 3
+	assert.ErrorIs(t, combine(a, b), ErrMismatchingMemberGroup, "mismatching member thresholds")
+
+	a, b = parse()
+	b.Value[0] ^= 1
+	assert.ErrorIs(t, combine(a, b), ErrInvalidDigest, "invalid digest")
+
+	a, _ = parse()
+	a.GroupThreshold = 2
+	_, err := ParseShare(a.Mnemonic())
+	assert.Equal(t, ErrInvalidGroupThreshold, err, "greater group threshold than group count")
+
+	a, _ = parse()
+	a.GroupCount, a.GroupIndex = 2, 2
+	_, err = ParseShare(a.Mnemonic())
+	assert.Equal(t, ErrInvalidGroupIndex, err, "group index out of range")
+
+	// as the reference decoder, a share of member threshold 1 may have any
+	// member index
+	a, _ = parse()
+	a.MemberThreshold, a.MemberIndex = 1, 1
+	share, err := ParseShare(a.Mnemonic())
+	assert.NoError(t, err, "member threshold 1 with a member index")
+	secret, err := CombineMnemonics([]string{share.Mnemonic()}, nil)
+	assert.NoError(t, err, "member threshold 1 with a member index")
+	assert.Len(t, secret, len(a.Value), "member threshold 1 with a member index")
+
+	a, _ = parse()
+	a.Value = append(a.Value, 0)
+	_, err = ParseShare(a.Mnemonic())
+	assert.Equal(t, ErrInvalidMnemonicLength, err, "invalid master secret length")
+
+	words := strings.Fields(mnemonics[0])
+	_, err = ParseShare(strings.Join(words[:MinMnemonicWords-1], " "))
+	assert.Equal(t, ErrInvalidMnemonicLength, err, "insufficient length")
+}
+
+func TestGenerateAndCombine(t *testing.T) {
+	secret, _ := hex.DecodeString("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20")
+	passphrase := []byte("TREZOR")
+	for _, extendable := range []bool{false, true} {
+		groups := []MemberGroup{{1, 1}, {2, 3}, {3, 5}}
+		mnemonics, err := GenerateMnemonics(2, groups, secret, passphrase, extendable, 0)
+		assert.NoError(t, err)
+		assert.Len(t, mnemonics, 3)
+		assert.Len(t, mnemonics[2], 5)
+
+		recovered, err := CombineMnemonics([]string{mnemonics[0][0], mnemonics[1][2], mnemonics[1][0]}, passphrase)
+		assert.NoError(t, err)
+		assert.Equal(t, secret, recovered)
+
+		recovered, err = CombineMnemonics([]string{mnemonics[2][4], mnemonics[1][1], mnemonics[2][0], mnemonics[1][2], mnemonics[2][2]}, passphrase)
+		assert.NoError(t, err)
+		assert.Equal(t, secret, recovered)
+
+		recovered, err = CombineMnemonics([]string{mnemonics[0][0], mnemonics[1][2], mnemonics[1][0]}, []byte("wrong"))
+		assert.NoError(t, err)
+		assert.NotEqual(t, secret, recovered)
+
+		_, err = CombineMnemonics([]string{mnemonics[0][0], mnemonics[1][2]}, passphrase)
+		assert.Equal(t, ErrInsufficientShares, err)
+
+		_, err = CombineMnemonics([]string{mnemonics[2][0], mnemonics[2][1], mnemonics[2][2]}, passphrase)
+		assert.Equal(t, ErrInsufficientGroups, err)
+
+		_, err = CombineMnemonics([]string{mnemonics[0][0], mnemonics[1][2], mnemonics[1][2]}, passphrase)
+		assert.Equal(t, ErrDuplicateMemberIndex, err)
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	secret := make([]byte, 16)
+	_, err := GenerateMnemonics(1, []MemberGroup{{1, 1}}, secret[:15], nil, false, 0)
+	assert.Equal(t, ErrInvalidSecretLength, err)
+	_, err = GenerateMnemonics(2, []MemberGroup{{1, 1}}, secret, nil, false, 0)
+	assert.Equal(t, ErrInvalidGroupThreshold, err)
+	_, err = GenerateMnemonics(1, []MemberGroup{{1, 2}}, secret, nil, false, 0)
+	assert.Equal(t, ErrInvalidMemberGroup, err)
+	_, err = GenerateMnemonics(1, []MemberGroup{{3, 2}}, secret, nil, false, 0)
+	assert.Equal(t, ErrInvalidThreshold, err)
+	_, err = GenerateMnemonics(1, []MemberGroup{{1, 1}}, secret, nil, false, 16)
+	assert.Equal(t, ErrInvalidIterationExp, err)
+}
+
+func TestWordList(t *testing.T) {
+	assert.Len(t, WordList, 1024)
+	prefixes := map[string]bool{}
+	for i, w := range WordList {
+		assert.Equal(t, i, wordMap[w])
+		prefixes[w[:4]] = true
+	}
+	assert.Len(t, prefixes, 1024)
+}
@@ -0,0 +1,133 @@
+// Package tron implements the addresses of Tron, the 0x41 prefix followed
+// by the Ethereum style address of the key, the last 20 bytes of the
+// keccak256 of the uncompressed public key, base58check encoded (T…) or hex.
+//
+// Ref https://developers.tron.network/docs/account#account-address-format
+package tron
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mearaj/bips/evm"
+	"github.com/mearaj/bips/netparams"
+)
+
+const (
+	// Prefix is the first byte of mainnet addresses
+	Prefix = 0x41
+	// AddressLength is the length of an address in bytes, prefix included
+	AddressLength = 1 + evm.AddressLength
+)
+
+var (
+	ErrInvalidPubKey   = errors.New("invalid public key")
+	ErrInvalidLength   = errors.New("invalid address length")
+	ErrInvalidPrefix   = errors.New("address must start with 0x41")
+	ErrInvalidHex      = errors.New("address is not hex")
+	ErrInvalidChecksum = errors.New("invalid base58check checksum")
+)
+
+// base58Params encode the base58check addresses, with the bitcoin alphabet
+// and double SHA256 checksum
+var base58Params = &netparams.Params{Name: "Tron"}
+
+// Address is a Tron address, prefix included
+type Address [AddressLength]byte
+
+// PubKeyToAddress returns the address of a compressed, uncompressed or
+// 64 bytes raw public key
+func PubKeyToAddress(pubKey []byte) (Address, error) {
+	evmAddr, err := evm.PubKeyToAddress(pubKey)
+	if err != nil {
+		return Address{}, ErrInvalidPubKey
+	}
+	return FromEVMAddress(evmAddr), nil
+}
+
+// FromEVMAddress returns the Tron address of the same key as the EVM address
+func FromEVMAddress(evmAddr evm.Address) Address {
+	var a Address
+	a[0] = Prefix
+	copy(a[1:], evmAddr[:])
+	return a
+}
+
+// ParseAddress parses a base58check address, ex TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqeL
+func ParseAddress(s string) (Address, error) {
+	var a Address
+	prefix, payload, err := base58Params.DecodeBase58Check(s, 1)
+	switch {
+	case errors.Is(err, netparams.ErrInvalidChecksum):
+		return a, ErrInvalidChecksum
+	case err != nil || len(payload) != evm.AddressLength:
+		return a, fmt.Errorf("%w: `%v`", ErrInvalidLength, s)
+	case prefix[0] != Prefix:
+		return a, fmt.Errorf("%w: `%x`", ErrInvalidPrefix, prefix[0])
+	}
+	a[0] = prefix[0]
+	copy(a[1:], payload)
+	return a, nil
+}
+
+// ParseHex parses a hex address, the 0x41 prefix included and 0x optional,
+// ex 418840e6c55b9ada326d211d818c34a994aeced808
+func ParseHex(s string) (Address, error) {
+	var a Address
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(digits) != 2*AddressLength {
+		return a, fmt.Errorf("%w: `%v`", ErrInvalidLength, s)
+	}
+	b, err := hex.DecodeString(digits)
+	if err != nil {
+		return a, ErrInvalidHex
+	}
+	if b[0] != Prefix {
+		return a, fmt.Errorf("%w: `%x`", ErrInvalidPrefix, b[0])
+	}
+	copy(a[:], b)
+	return a, nil
+}
+
+// HexToBase58 converts a hex address to its base58check form
+func HexToBase58(s string) (string, error) {
+	a, err := ParseHex(s)
+	if err != nil {
+		return "", err
+	}
+	return a.Base58(), nil
+}
+
+// Base58ToHex converts a base58check address to its hex form
+func Base58ToHex(s string) (string, error) {
+	a, err := ParseAddress(s)
+	if err != nil {
+		return "", err
+	}
+	return a.Hex(), nil
+}
+
+// Base58 returns the base58check address, ex TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqeL
+func (a Address) Base58() string {
+	// the double SHA256 checksum never fails
+	s, _ := base58Params.EncodeBase58Check(a[:1], a[1:])
+	return s
+}
+
+// Hex returns the lower case hex of the address, prefix included, without 0x
+func (a Address) Hex() string {
+	return hex.EncodeToString(a[:])
+}
+
+// EVMAddress returns the address without its prefix, the EVM address of the key
+func (a Address) EVMAddress() evm.Address {
+	var evmAddr evm.Address
+	copy(evmAddr[:], a[1:])
+	return evmAddr
+}
+
+func (a Address) String() string {
+	return a.Base58()
+}
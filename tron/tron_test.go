@@ -0,0 +1,57 @@
+package tron
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/mearaj/bips/evm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHexBase58(t *testing.T) {
+	vectors := []struct {
+		hex    string
+		base58 string
+	}{
+		{"418840e6c55b9ada326d211d818c34a994aeced808", "TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqeL"},
+	}
+	for _, v := range vectors {
+		b58, err := HexToBase58(v.hex)
+		require.NoError(t, err)
+		assert.Equal(t, v.base58, b58)
+		h, err := Base58ToHex(v.base58)
+		require.NoError(t, err)
+		assert.Equal(t, v.hex, h)
+	}
+}
+
+func TestPubKeyToAddress(t *testing.T) {
+	// m/44'/195'/0'/0/0 of the mnemonic abandon … about
+	pubKey, _ := hex.DecodeString("03ff21f8e64d3a3c0198edfbb7afdc79be959432e92e2f8a1984bb436a414b8edc")
+	a, err := PubKeyToAddress(pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH", a.String())
+	assert.Equal(t, "41c8599111f29c1e1e061265b4af93ea1f274ad78a", a.Hex())
+	evmAddr, err := evm.PubKeyToAddress(pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, evmAddr, a.EVMAddress())
+	assert.Equal(t, a, FromEVMAddress(evmAddr))
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := ParseAddress("TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqeM")
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+	_, err = ParseAddress("1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA")
+	assert.ErrorIs(t, err, ErrInvalidPrefix)
+	_, err = ParseAddress("TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqe0")
+	assert.ErrorIs(t, err, ErrInvalidLength)
+	_, err = ParseHex("418840e6c55b9ada326d211d818c34a994aeced8")
+	assert.ErrorIs(t, err, ErrInvalidLength)
+	_, err = ParseHex("428840e6c55b9ada326d211d818c34a994aeced808")
+	assert.ErrorIs(t, err, ErrInvalidPrefix)
+	_, err = ParseHex("418840e6c55b9ada326d211d818c34a994aeced80g")
+	assert.ErrorIs(t, err, ErrInvalidHex)
+	_, err = PubKeyToAddress([]byte{0x02})
+	assert.ErrorIs(t, err, ErrInvalidPubKey)
+}
@@ -3,9 +3,18 @@ package util
 import (
 	"encoding/hex"
 	"fmt"
-	"github.com/btcsuite/btcutil/base58"
+	"github.com/mearaj/bips/address"
+	"github.com/mearaj/bips/bech32"
 	"github.com/mearaj/bips/bip32"
-	"golang.org/x/crypto/sha3"
+	"github.com/mearaj/bips/bip44"
+	"github.com/mearaj/bips/bip86"
+	"github.com/mearaj/bips/cashaddr"
+	"github.com/mearaj/bips/cosmos"
+	"github.com/mearaj/bips/eos"
+	"github.com/mearaj/bips/evm"
+	"github.com/mearaj/bips/filecoin"
+	"github.com/mearaj/bips/netparams"
+	"github.com/mearaj/bips/tron"
 )
 
 type KeyPath struct {
@@ -13,58 +22,214 @@ type KeyPath struct {
 	Key  bip32.Key
 }
 
-func (b KeyPath) AddrHex() string {
+// ExtendedKey returns the base58 serialization of the key with the checksum
+// of the coin of its path, ex the Groestl-512 one of m/44'/17'/0'
+func (b KeyPath) ExtendedKey() string {
+	return b.Key.B58SerializeWith(bip32.VersionBytesOfPath(b.Path, b.Key.GetVersion()))
+}
+
+// ExtendedPubKey returns the base58 serialization of the extended public
+// key of the key with the checksum of the coin of its path
+func (b KeyPath) ExtendedPubKey() string {
+	pub := b.Key.PublicKeyExtended()
+	return pub.B58SerializeWith(bip32.VersionBytesOfPath(b.Path, pub.GetVersion()))
+}
+
+// Address returns the address of the key for the coin in the encoding, with
+// the encoder registered in the address package. The error wraps
+// address.ErrNotSupported when the coin has no such encoder.
+func (b KeyPath) Address(coin bip44.Coin, encoding bip32.AddrEncoding) (string, error) {
 	pbs, err := hex.DecodeString(b.Key.PublicKeyHex())
+	if err != nil {
+		return "", err
+	}
+	return address.Encode(coin, encoding, pbs)
+}
+
+// AddrHex returns the lower case hex EVM address of the key, without 0x
+func (b KeyPath) AddrHex() string {
+	addr, err := b.AddrEVM()
 	if err != nil {
 		return ""
 	}
-	x, y := bip32.ExpandPublicKey(pbs)
-	addrBs := append(x.Bytes(), y.Bytes()...)
-	kckHash := sha3.NewLegacyKeccak256()
-	kckHash.Write(addrBs)
-	return hex.EncodeToString(kckHash.Sum(nil)[12:])
+	return addr.LowerHex()
 }
 
-func (b KeyPath) AddrP2SH(verPrefix byte) string {
+// AddrEVM returns the Ethereum and EVM chains address of the key, its Hex
+// method formats it with the EIP-55 checksum
+func (b KeyPath) AddrEVM() (evm.Address, error) {
 	pbs, err := hex.DecodeString(b.Key.PublicKeyHex())
 	if err != nil {
-		return ""
+		return evm.Address{}, err
 	}
-	pubKeyHash, err := bip32.HashRipeMD160onSha256(pbs)
+	return evm.PubKeyToAddress(pbs)
+}
+
+// AddrP2SH returns the P2PKH address of the key for the version byte
+// verPrefix, despite its name it doesn't hash any script.
+//
+// Deprecated: use AddrP2PKH with the network parameters, or
+// netparams.Params.AddrP2SH for script hash addresses.
+func (b KeyPath) AddrP2SH(verPrefix byte) string {
+	addr, err := b.AddrP2PKH(&netparams.Params{PubKeyHashPrefix: []byte{verPrefix}})
 	if err != nil {
 		return ""
 	}
-	verPubKeyHash := append([]byte{verPrefix}, pubKeyHash...)
-	chkSum, err := bip32.ChecksumDblSha256(verPubKeyHash)
+	return addr
+}
+
+// AddrP2PKH returns the pay to pubkey hash address of the key on the
+// network of params, ex netparams.Bitcoin
+func (b KeyPath) AddrP2PKH(params *netparams.Params) (string, error) {
+	pbs, err := hex.DecodeString(b.Key.PublicKeyHex())
 	if err != nil {
-		return ""
+		return "", err
+	}
+	return params.AddrP2PKH(pbs)
+}
+
+// AddrP2WPKH returns the BIP173 native segwit address of the key for the
+// bech32 human readable part hrp, ex bech32.HRPBitcoin or VersionBytes.HRP
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0084.mediawiki
+func (b KeyPath) AddrP2WPKH(hrp string) (string, error) {
+	pubKeyHash, err := b.pubKeyHash()
+	if err != nil {
+		return "", err
+	}
+	return bech32.EncodeSegWitAddress(hrp, 0, pubKeyHash)
+}
+
+// AddrP2WPKHInP2SH returns the BIP49 nested segwit address of the key, the
+// P2SH address of its P2WPKH redeem script, on the network of params,
+// ex netparams.Bitcoin
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0049.mediawiki
+func (b KeyPath) AddrP2WPKHInP2SH(params *netparams.Params) (string, error) {
+	redeemScript, err := b.RedeemScriptP2WPKH()
+	if err != nil {
+		return "", err
+	}
+	return params.AddrP2SH(redeemScript)
+}
+
+// RedeemScriptP2WPKH returns the witness version 0 script of the key hash,
+// OP_0 followed by the push of the 20 bytes key hash
+func (b KeyPath) RedeemScriptP2WPKH() ([]byte, error) {
+	pubKeyHash, err := b.pubKeyHash()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{0x00, 0x14}, pubKeyHash...), nil
+}
+
+// AddrP2TR returns the BIP86 taproot address of the key for the bech32
+// human readable part hrp. The output key commits to the script tree
+// merkleRoot, nil for key path only spending as in BIP86.
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0086.mediawiki
+func (b KeyPath) AddrP2TR(hrp string, merkleRoot []byte) (string, error) {
+	pbs, err := hex.DecodeString(b.Key.PublicKeyHex())
+	if err != nil {
+		return "", err
+	}
+	outputKey, err := bip86.TweakPubKey(pbs, merkleRoot)
+	if err != nil {
+		return "", err
+	}
+	return bech32.EncodeSegWitAddress(hrp, 1, outputKey)
+}
+
+// AddrCashAddr returns the CashAddr P2PKH address of the key for the
+// prefix, ex cashaddr.PrefixBitcoinCash
+// Ref https://github.com/bitcoincashorg/bitcoincash.org/blob/master/spec/cashaddr.md
+func (b KeyPath) AddrCashAddr(prefix string) (string, error) {
+	pubKeyHash, err := b.pubKeyHash()
+	if err != nil {
+		return "", err
 	}
-	addBs := append(verPubKeyHash, chkSum...)
-	return base58.Encode(addBs)
-}
-
-//func (b KeyPath) PvtKeyInWIF(compress bool, netPrefix byte) string {
-//	hexToConvert := fmt.Sprintf("%x%s", netPrefix, b.Key.PrivateKeyHex())
-//	if compress {
-//		hexToConvert += "01"
-//	}
-//	pvtKeyBs, err := hex.DecodeString(hexToConvert)
-//	if err != nil {
-//		return ""
-//	}
-//	pvtKeyDblChkSum, err := bip32.ChecksumDblSha256(pvtKeyBs)
-//	if err != nil {
-//		return ""
-//	}
-//	pvtKeyDblChkSumHex := hex.EncodeToString(pvtKeyDblChkSum)
-//	pvtKeyHex := hexToConvert + pvtKeyDblChkSumHex
-//	pvtKeyHexBs, err := hex.DecodeString(pvtKeyHex)
-//	if err != nil {
-//		return ""
-//	}
-//	pvtKey58 := base58.Encode(pvtKeyHexBs)
-//	return pvtKey58
-//}
+	return cashaddr.Encode(prefix, cashaddr.P2PKH, pubKeyHash)
+}
+
+// AddrP2PKT returns the Nexa pay to public key template address of the key
+// for the prefix, ex cashaddr.PrefixNexa
+func (b KeyPath) AddrP2PKT(prefix string) (string, error) {
+	pbs, err := hex.DecodeString(b.Key.PublicKeyHex())
+	if err != nil {
+		return "", err
+	}
+	return cashaddr.AddrP2PKT(prefix, pbs)
+}
+
+// AddrTron returns the Tron address of the key, its Base58 method formats
+// it as T… and its Hex method with the 0x41 prefix
+func (b KeyPath) AddrTron() (tron.Address, error) {
+	pbs, err := hex.DecodeString(b.Key.PublicKeyHex())
+	if err != nil {
+		return tron.Address{}, err
+	}
+	return tron.PubKeyToAddress(pbs)
+}
+
+// AddrCosmos returns the Cosmos SDK address of the key for the bech32 human
+// readable part hrp, ex cosmos.Osmosis.HRP or the one of cosmos.Chain.HRPOf
+func (b KeyPath) AddrCosmos(hrp string) (string, error) {
+	pbs, err := hex.DecodeString(b.Key.PublicKeyHex())
+	if err != nil {
+		return "", err
+	}
+	return cosmos.Address(hrp, pbs)
+}
+
+// AddrFilecoin returns the Filecoin secp256k1 address of the key, f1… or
+// t1… for the testnet
+func (b KeyPath) AddrFilecoin(testnet bool) (filecoin.Address, error) {
+	pbs, err := hex.DecodeString(b.Key.PublicKeyHex())
+	if err != nil {
+		return filecoin.Address{}, err
+	}
+	return filecoin.PubKeyToAddress(pbs, testnet)
+}
+
+// PubKeyEOS returns the EOS… public key of the key, PUB_K1_… if k1
+func (b KeyPath) PubKeyEOS(k1 bool) (string, error) {
+	pbs, err := hex.DecodeString(b.Key.PublicKeyHex())
+	if err != nil {
+		return "", err
+	}
+	if k1 {
+		return eos.PubKeyToK1String(pbs)
+	}
+	return eos.PubKeyToString(pbs)
+}
+
+// PvtKeyEOS returns the legacy WIF private key of the key, PVT_K1_… if k1
+func (b KeyPath) PvtKeyEOS(k1 bool) (string, error) {
+	pvs, err := hex.DecodeString(b.Key.PrivateKeyHex())
+	if err != nil {
+		return "", err
+	}
+	if k1 {
+		return eos.PvtKeyToK1String(pvs)
+	}
+	return eos.PvtKeyToWIF(pvs)
+}
+
+func (b KeyPath) pubKeyHash() ([]byte, error) {
+	pbs, err := hex.DecodeString(b.Key.PublicKeyHex())
+	if err != nil {
+		return nil, err
+	}
+	return bip32.HashRipeMD160onSha256(pbs)
+}
+
+// PvtKeyInWIF returns the private key of the key in the wallet import
+// format of the network, with the compressed flag if compressed, ex
+// PvtKeyInWIF(netparams.Bitcoin, true) for K… and L… keys
+func (b KeyPath) PvtKeyInWIF(params *netparams.Params, compressed bool) (string, error) {
+	pvs, err := hex.DecodeString(b.Key.PrivateKeyHex())
+	if err != nil {
+		return "", err
+	}
+	return params.EncodeWIF(pvs, compressed)
+}
 
 type KeyPathRange struct {
 	StartIndex uint32
@@ -0,0 +1,307 @@
+package util
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/mearaj/bips/address"
+	"github.com/mearaj/bips/bech32"
+	"github.com/mearaj/bips/bip32"
+	"github.com/mearaj/bips/bip44"
+	"github.com/mearaj/bips/cashaddr"
+	"github.com/mearaj/bips/cosmos"
+	"github.com/mearaj/bips/eos"
+	"github.com/mearaj/bips/netparams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func testKeyPaths(t *testing.T, path string) []KeyPath {
+	seed, err := DeriveSeedFromMnemonic(testMnemonic, "")
+	require.NoError(t, err)
+	rootKey, err := RootKeyFromSeed(seed)
+	require.NoError(t, err)
+	var g Generator
+	g.SetRootKey(*rootKey)
+	keyPaths, err := g.DeriveBIP32Result(Path(path))
+	require.NoError(t, err)
+	return keyPaths
+}
+
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0084.mediawiki#test-vectors
+func TestAddrP2WPKH(t *testing.T) {
+	vectors := []struct {
+		path    string
+		pubKey  string
+		address string
+	}{
+		{"m/84'/0'/0'/0/0", "0330d54fd0dd420a6e5f8d3624f5f3482cae350f79d5f0753bf5beef9c2d91af3c", "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{"m/84'/0'/0'/0/1", "03e775fd51f0dfb8cd865d9ff1cca2a158cf651fe997fdc9fee9c1d3b5e995ea77", "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g"},
+		{"m/84'/0'/0'/1/0", "03025324888e429ab8e3dbaf1f7802648b9cd01e9b418485c5fa4c1b9b5700e1a6", "bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el"},
+	}
+	for _, v := range vectors {
+		keyPaths := testKeyPaths(t, v.path)
+		keyPath := keyPaths[len(keyPaths)-1]
+		assert.Equal(t, v.pubKey, keyPath.Key.PublicKeyHex())
+		addr, err := keyPath.AddrP2WPKH(bip32.Bitcoinzprvzpub.HRP)
+		assert.NoError(t, err)
+		assert.Equal(t, v.address, addr)
+	}
+
+	keyPaths := testKeyPaths(t, "m/84'/0'/0'")
+	account := keyPaths[len(keyPaths)-1].Key.PublicKeyExtended()
+	account.SetVersion(bip32.Version(bip32.Bitcoinzprvzpub.PubKeyFlagBytes()))
+	assert.Equal(t, "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs", account.String())
+
+	keyPaths = testKeyPaths(t, "m/84'/0'/0'/0/0")
+	addr, err := keyPaths[len(keyPaths)-1].AddrP2WPKH(bech32.HRPLitecoin)
+	assert.NoError(t, err)
+	version, program, err := bech32.DecodeSegWitAddress(bech32.HRPLitecoin, addr)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0), version)
+	hash, _ := keyPaths[len(keyPaths)-1].pubKeyHash()
+	assert.Equal(t, hash, program)
+}
+
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0049.mediawiki#test-vectors
+func TestAddrP2WPKHInP2SH(t *testing.T) {
+	keyPaths := testKeyPaths(t, "m/49'/1'/0'/0/0")
+	root := keyPaths[0].Key
+	root.SetVersion(bip32.Version(bip32.Bitcoinuprvupub.PvtKeyFlagBytes()))
+	assert.Equal(t, "uprv8tXDerPXZ1QsVNjUJWTurs9kA1KGfKUAts74GCkcXtU8GwnH33GDRbNJpEqTvipfCyycARtQJhmdfWf8oKt41X9LL1zeD2pLsWmxEk3VAwd", root.String())
+	account := keyPaths[3].Key
+	account.SetVersion(bip32.Version(bip32.Bitcoinuprvupub.PvtKeyFlagBytes()))
+	assert.Equal(t, "uprv91G7gZkzehuMVxDJTYE6tLivdF8e4rvzSu1LFfKw3b2Qx1Aj8vpoFnHdfUZ3hmi9jsvPifmZ24RTN2KhwB8BfMLTVqaBReibyaFFcTP1s9n", account.String())
+	accountPub := keyPaths[3].Key.PublicKeyExtended()
+	accountPub.SetVersion(bip32.Version(bip32.Bitcoinuprvupub.PubKeyFlagBytes()))
+	assert.Equal(t, "upub5EFU65HtV5TeiSHmZZm7FUffBGy8UKeqp7vw43jYbvZPpoVsgU93oac7Wk3u6moKegAEWtGNF8DehrnHtv21XXEMYRUocHqguyjknFHYfgY", accountPub.String())
+
+	keyPath := keyPaths[len(keyPaths)-1]
+	assert.Equal(t, "03a1af804ac108a8a51782198c2d034b28bf90c8803f5a53f76276fa69a4eae77f", keyPath.Key.PublicKeyHex())
+	redeemScript, err := keyPath.RedeemScriptP2WPKH()
+	assert.NoError(t, err)
+	assert.Equal(t, "001438971f73930f6c141d977ac4fd4a727c854935b3", hex.EncodeToString(redeemScript))
+	addr, err := keyPath.AddrP2WPKHInP2SH(netparams.BitcoinTestnet)
+	assert.NoError(t, err)
+	assert.Equal(t, "2Mww8dCYPUpKHofjgcXcBCEGmniw9CoaiD2", addr)
+
+	// the first receiving addresses of the mnemonic on the mainnets of
+	// Bitcoin and Litecoin, Litecoin with its M… script hash prefix
+	for _, v := range []struct {
+		path    string
+		params  *netparams.Params
+		address string
+	}{
+		{"m/49'/0'/0'/0/0", netparams.Bitcoin, "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf"},
+		{"m/49'/0'/0'/0/1", netparams.Bitcoin, "3LtMnn87fqUeHBUG414p9CWwnoV6E2pNKS"},
+		{"m/49'/2'/0'/0/0", netparams.Litecoin, "M7wtsL7wSHDBJVMWWhtQfTMSYYkyooAAXM"},
+	} {
+		keyPaths = testKeyPaths(t, v.path)
+		addr, err = keyPaths[len(keyPaths)-1].AddrP2WPKHInP2SH(v.params)
+		assert.NoError(t, err, v.path)
+		assert.Equal(t, v.address, addr, v.path)
+	}
+}
+
+// Ref https://github.com/bitcoin/bips/blob/master/bip-0086.mediawiki#test-vectors
+func TestAddrP2TR(t *testing.T) {
+	vectors := []struct {
+		path    string
+		address string
+	}{
+		{"m/86'/0'/0'/0/0", "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+		{"m/86'/0'/0'/0/1", "bc1p4qhjn9zdvkux4e44uhx8tc55attvtyu358kutcqkudyccelu0was9fqzwh"},
+		{"m/86'/0'/0'/1/0", "bc1p3qkhfews2uk44qtvauqyr2ttdsw7svhkl9nkm9s9c3x4ax5h60wqwruhk7"},
+	}
+	for _, v := range vectors {
+		keyPaths := testKeyPaths(t, v.path)
+		addr, err := keyPaths[len(keyPaths)-1].AddrP2TR(bech32.HRPBitcoin, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, v.address, addr)
+	}
+
+	keyPaths := testKeyPaths(t, "m/86'/0'/0'")
+	account := keyPaths[len(keyPaths)-1].Key
+	assert.Equal(t, "xprv9xgqHN7yz9MwCkxsBPN5qetuNdQSUttZNKw1dcYTV4mkaAFiBVGQziHs3NRSWMkCzvgjEe3n9xV8oYywvM8at9yRqyaZVz6TYYhX98VjsUk", account.String())
+	accountPub := account.PublicKeyExtended()
+	assert.Equal(t, "xpub6BgBgsespWvERF3LHQu6CnqdvfEvtMcQjYrcRzx53QJjSxarj2afYWcLteoGVky7D3UKDP9QyrLprQ3VCECoY49yfdDEHGCtMMj92pReUsQ", accountPub.String())
+
+	keyPaths = testKeyPaths(t, "m/86'/1'/0'/0/0")
+	addr, err := keyPaths[len(keyPaths)-1].AddrP2TR(bech32.HRPBitcoinTestnet, make([]byte, 32))
+	assert.NoError(t, err)
+	assert.Regexp(t, "^tb1p", addr)
+}
+
+func TestAddrP2PKH(t *testing.T) {
+	keyPaths := testKeyPaths(t, "m/44'/0'/0'/0/0")
+	keyPath := keyPaths[len(keyPaths)-1]
+	addr, err := keyPath.AddrP2PKH(netparams.Bitcoin)
+	assert.NoError(t, err)
+	assert.Equal(t, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", addr)
+	assert.Equal(t, addr, keyPath.AddrP2SH(0x00))
+}
+
+func TestAddrEVM(t *testing.T) {
+	keyPaths := testKeyPaths(t, "m/44'/60'/0'/0/0")
+	keyPath := keyPaths[len(keyPaths)-1]
+	addr, err := keyPath.AddrEVM()
+	assert.NoError(t, err)
+	assert.Equal(t, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", addr.Hex())
+	assert.Equal(t, "9858effd232b4033e47d90003d41ec34ecaeda94", keyPath.AddrHex())
+}
+
+func TestAddress(t *testing.T) {
+	bitcoin := bip44.RegBip44CoinsTypeToValMap[netparams.CoinTypeBitcoin]
+	vectors := []struct {
+		path     string
+		coin     bip44.Coin
+		encoding bip32.AddrEncoding
+		address  string
+	}{
+		{"m/44'/0'/0'/0/0", bitcoin, bip32.P2PKH, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA"},
+		{"m/49'/0'/0'/0/0", bitcoin, bip32.P2WPKHInP2SH, "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf"},
+		{"m/84'/0'/0'/0/0", bitcoin, bip32.P2WPKH, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{"m/86'/0'/0'/0/0", bitcoin, bip32.P2TR, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+		{"m/44'/60'/0'/0/0", bip44.RegBip44CoinsTypeToValMap[60], bip32.EVMHex, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"},
+		{"m/44'/144'/0'/0/0", netparams.Ripple.Coin, bip32.P2PKH, "rHsMGQEkVNJmpGWs8XUBoTBiAAbwxZN5v3"},
+		{"m/44'/195'/0'/0/0", bip44.RegBip44CoinsTypeToValMap[195], bip32.TronBase58, "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH"},
+		{"m/44'/118'/0'/0/0", bip44.RegBip44CoinsTypeToValMap[118], bip32.Cosmos, "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4"},
+		{"m/44'/461'/0'/0/0", bip44.RegBip44CoinsTypeToValMap[461], bip32.FilecoinSecp256k1, "f1qode47ievxlxzk6z2viuovedabmn3tq6t57uqhq"},
+		{"m/44'/194'/0'/0/0", bip44.RegBip44CoinsTypeToValMap[194], bip32.EOSPubKey, "EOS6zpSNY1YoLxNt2VsvJjoDfBueU6xC1M1ERJw1UoekL1NHn8KNA"},
+		{"m/44'/194'/0'/0/0", bip44.RegBip44CoinsTypeToValMap[194], bip32.EOSPubKeyK1, "PUB_K1_6zpSNY1YoLxNt2VsvJjoDfBueU6xC1M1ERJw1UoekL1NK2aD4t"},
+	}
+	for _, v := range vectors {
+		keyPaths := testKeyPaths(t, v.path)
+		addr, err := keyPaths[len(keyPaths)-1].Address(v.coin, v.encoding)
+		assert.NoError(t, err, v.path)
+		assert.Equal(t, v.address, addr, v.path)
+	}
+
+	keyPaths := testKeyPaths(t, "m/44'/60'/0'/0/0")
+	_, err := keyPaths[len(keyPaths)-1].Address(bip44.RegBip44CoinsTypeToValMap[60], bip32.P2WPKH)
+	assert.ErrorIs(t, err, address.ErrNotSupported)
+}
+
+func TestAddrFilecoin(t *testing.T) {
+	keyPaths := testKeyPaths(t, "m/44'/461'/0'/0/0")
+	keyPath := keyPaths[len(keyPaths)-1]
+	addr, err := keyPath.AddrFilecoin(true)
+	assert.NoError(t, err)
+	assert.Equal(t, "t1qode47ievxlxzk6z2viuovedabmn3tq6t57uqhq", addr.String())
+	decoded, err := address.Decode(addr.String())
+	assert.NoError(t, err)
+	assert.Equal(t, addr.Payload[:], decoded.Payload)
+}
+
+func TestPvtKeyInWIF(t *testing.T) {
+	keyPaths := testKeyPaths(t, "m/44'/0'/0'/0/0")
+	keyPath := keyPaths[len(keyPaths)-1]
+	wif, err := keyPath.PvtKeyInWIF(netparams.Bitcoin, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "L4p2b9VAf8k5aUahF1JCJUzZkgNEAqLfq8DDdQiyAprQAKSbu8hf", wif)
+	wif, err = keyPath.PvtKeyInWIF(netparams.Bitcoin, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "5KY3dHRWNnFkBJaTnmUTaR1oqs9tU9goQbG19FSNLSo5oAxLokG", wif)
+	decoded, err := netparams.DecodeWIF(wif)
+	assert.NoError(t, err)
+	assert.Equal(t, keyPath.Key.PrivateKeyHex(), hex.EncodeToString(decoded.PvtKey))
+	assert.False(t, decoded.Compressed)
+	assert.Contains(t, decoded.Networks, netparams.Bitcoin)
+
+	// public keys have no WIF
+	publicKeyPath := keyPath
+	publicKeyPath.Key = publicKeyPath.Key.PublicKeyExtended()
+	_, err = publicKeyPath.PvtKeyInWIF(netparams.Bitcoin, true)
+	assert.ErrorIs(t, err, netparams.ErrInvalidPvtKey)
+}
+
+// The Groestlcoin account is the one of the mnemonic "all all ... all" of the
+// Trezor device tests, see netparams TestEncodeKey
+func TestExtendedKey(t *testing.T) {
+	keyPaths := testKeyPaths(t, "m/44'/0'/0'")
+	keyPath := keyPaths[len(keyPaths)-1]
+	assert.Equal(t, "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj", keyPath.ExtendedPubKey())
+	assert.Equal(t, keyPath.Key.String(), keyPath.ExtendedKey())
+
+	seed, err := DeriveSeedFromMnemonic(strings.TrimSpace(strings.Repeat("all ", 12)), "")
+	require.NoError(t, err)
+	rootKey, err := RootKeyFromSeed(seed)
+	require.NoError(t, err)
+	var g Generator
+	g.SetRootKey(*rootKey)
+	keyPaths, err = g.DeriveBIP32Result("m/44'/17'/0'")
+	require.NoError(t, err)
+	keyPath = keyPaths[len(keyPaths)-1]
+	assert.Equal(t, "xpub6DGa4qjCDqDNhLWUCEPJSETizUdexA2i5k3wUG2QboRNa4MNJV4k5XthorGcogStY5K5iJ6NHtsznNK599ir8PmA3d1jqEoZHsixDTddNA9", keyPath.ExtendedPubKey())
+	expected, err := netparams.Groestlcoin.EncodeKey(&keyPath.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, keyPath.ExtendedKey())
+	assert.NotEqual(t, keyPath.Key.String(), keyPath.ExtendedKey())
+}
+
+func TestPvtKeyEOS(t *testing.T) {
+	keyPaths := testKeyPaths(t, "m/44'/194'/0'/0/0")
+	keyPath := keyPaths[len(keyPaths)-1]
+	wif, err := keyPath.PvtKeyEOS(false)
+	assert.NoError(t, err)
+	assert.Equal(t, "5K2VtCafACZx6iiN5xyBb67UszFQa6yVLR8UquZU2x6aPmbQnU6", wif)
+	k1, err := keyPath.PvtKeyEOS(true)
+	assert.NoError(t, err)
+	assert.Equal(t, "PVT_K1_2DDJX8a5jzxqiL63mXHW3NMfPRVMy7Qcc4hcjWio5EwE85iMPJ", k1)
+	pvtKey, err := eos.ParsePvtKey(k1)
+	assert.NoError(t, err)
+	assert.Equal(t, keyPath.Key.PrivateKeyHex(), hex.EncodeToString(pvtKey))
+}
+
+func TestAddrCosmos(t *testing.T) {
+	keyPaths := testKeyPaths(t, "m/44'/118'/0'/0/0")
+	keyPath := keyPaths[len(keyPaths)-1]
+	addr, err := keyPath.AddrCosmos(cosmos.CosmosHub.HRP)
+	assert.NoError(t, err)
+	assert.Equal(t, "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4", addr)
+	addr, err = keyPath.AddrCosmos(cosmos.Osmosis.HRP)
+	assert.NoError(t, err)
+	assert.Equal(t, "osmo19rl4cm2hmr8afy4kldpxz3fka4jguq0a5m7df8", addr)
+}
+
+func TestAddrCashAddr(t *testing.T) {
+	keyPaths := testKeyPaths(t, "m/44'/145'/0'/0/0")
+	keyPath := keyPaths[len(keyPaths)-1]
+	addr, err := keyPath.AddrCashAddr(cashaddr.PrefixBitcoinCash)
+	require.NoError(t, err)
+	legacy, err := keyPath.AddrP2PKH(netparams.BitcoinCash)
+	require.NoError(t, err)
+	converted, err := cashaddr.ToLegacy(addr)
+	require.NoError(t, err)
+	assert.Equal(t, legacy, converted)
+
+	addr, err = keyPath.AddrP2PKT(cashaddr.PrefixNexa)
+	require.NoError(t, err)
+	_, typ, _, err := cashaddr.Decode(addr, "")
+	require.NoError(t, err)
+	assert.Equal(t, cashaddr.P2PKT, typ)
+}
+
+func TestDecredDerivation(t *testing.T) {
+	seed, err := DeriveSeedFromMnemonic(testMnemonic, "")
+	require.NoError(t, err)
+	rootKey, err := RootKeyFromSeed(seed)
+	require.NoError(t, err)
+	rootKey.SetVersion(bip32.Decreddprvdpub.PvtKeyFlagBytes())
+	var g Generator
+	g.SetRootKey(*rootKey)
+	keyPaths, err := g.DeriveBIP32Result("m/44'/42'/0'/0/0")
+	require.NoError(t, err)
+	for _, keyPath := range keyPaths {
+		assert.Regexp(t, "^dprv", keyPath.Key.String())
+		key, err := bip32.B58Deserialize(keyPath.Key.String())
+		assert.NoError(t, err)
+		assert.Equal(t, keyPath.Key, key)
+	}
+	addr, err := keyPaths[len(keyPaths)-1].AddrP2PKH(netparams.Decred)
+	require.NoError(t, err)
+	assert.Regexp(t, "^Ds", addr)
+}